@@ -0,0 +1,240 @@
+package mws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Region groups of the marketplaces, the marketplaces of a group are
+// 	managed by the same seller account.
+const (
+	GroupNA = "NA"
+	GroupEU = "EU"
+	GroupFE = "FE"
+	GroupCN = "CN"
+)
+
+// Marketplace the attributes of a marketplace.
+// http://docs.developer.amazonservices.com/en_US/dev_guide/DG_Endpoints.html
+type Marketplace struct {
+	// Marketplace id, ex: ATVPDKIKX0DER.
+	ID string
+	// Two character country code used as the region of the client, ex: US.
+	// Note: UK is used for the United Kingdom, GB is accepted as lookup key.
+	CountryCode string
+	// Domain of the Amazon website, ex: amazon.com.
+	Domain string
+	// The MWS host, ex: mws.amazonservices.com.
+	EndPoint string
+	// ISO 4217 currency code, ex: USD.
+	Currency string
+	// Default locale, ex: en_US.
+	Locale string
+	// Default charset of the flat files, ex: ISO-8859-1.
+	Encoding string
+	// Region group, NA, EU, FE or CN.
+	Group string
+}
+
+// Catalog a collection of marketplaces, can be looked up by id, country code
+// 	or domain.
+// Safe for concurrent use.
+type Catalog struct {
+	mu           sync.RWMutex
+	marketplaces []Marketplace
+
+	// The legacy EndPoints and MarketPlaceIds of the DefaultCatalog, read as
+	// 	the fallback of the lookups and updated by the registers.
+	legacyEndPoints map[string]string
+	legacyIds       map[string]string
+}
+
+// NewCatalog create a catalog of the marketplaces.
+func NewCatalog(marketplaces ...Marketplace) *Catalog {
+	catalog := &Catalog{}
+	for _, mp := range marketplaces {
+		catalog.Register(mp)
+	}
+	return catalog
+}
+
+// Register add the marketplace to the catalog, replace the one with the
+// 	same id.
+// ID, CountryCode and EndPoint are required.
+func (c *Catalog) Register(mp Marketplace) error {
+	if mp.ID == "" || mp.CountryCode == "" || mp.EndPoint == "" {
+		return fmt.Errorf("Marketplace %v missing id, country code or endpoint", mp.ID)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.legacyEndPoints != nil {
+		c.legacyEndPoints[mp.ID] = mp.EndPoint
+	}
+	if c.legacyIds != nil {
+		c.legacyIds[mp.CountryCode] = mp.ID
+	}
+	for i := range c.marketplaces {
+		if c.marketplaces[i].ID == mp.ID {
+			c.marketplaces[i] = mp
+			return nil
+		}
+	}
+	c.marketplaces = append(c.marketplaces, mp)
+	return nil
+}
+
+// RegisterEndPoint set a custom endpoint for the marketplace, ex: the host of
+// 	a mock server. The key can be id, country code or domain.
+func (c *Catalog) RegisterEndPoint(key, endPoint string) error {
+	mp, ok := c.Lookup(key)
+	if !ok {
+		return MarketPlaceError{"marketplace", key}
+	}
+	mp.EndPoint = endPoint
+	return c.Register(mp)
+}
+
+// Lookup find the marketplace by id, country code or domain. The country
+// 	code and domain are case insensitive, ex: "ATVPDKIKX0DER", "us" and
+// 	"www.amazon.com" are all look up the US marketplace.
+func (c *Catalog) Lookup(key string) (Marketplace, bool) {
+	key = strings.TrimSpace(key)
+	lower := strings.TrimPrefix(strings.ToLower(key), "www.")
+	country := strings.ToUpper(key)
+	if country == "GB" {
+		country = "UK"
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	mp, ok := c.lookup(func(mp Marketplace) bool {
+		return mp.ID == key || mp.CountryCode == country || (mp.Domain != "" && mp.Domain == lower)
+	})
+
+	// The legacy maps may be changed by the callers, the id of the region
+	// 	and the endpoint of the id in the maps take precedence.
+	if id, found := c.legacyIds[country]; found && id != mp.ID && (!ok || mp.CountryCode == country) {
+		mp, ok = c.lookup(func(mp Marketplace) bool { return mp.ID == id })
+		if !ok {
+			mp, ok = Marketplace{ID: id, CountryCode: country}, true
+		}
+	}
+	if _, found := c.legacyEndPoints[key]; found && !ok {
+		mp, ok = Marketplace{ID: key}, true
+	}
+	if endPoint, found := c.legacyEndPoints[mp.ID]; found && ok && endPoint != "" {
+		mp.EndPoint = endPoint
+	}
+	return mp, ok
+}
+
+// lookup return the first marketplace match.
+func (c *Catalog) lookup(match func(mp Marketplace) bool) (Marketplace, bool) {
+	for _, mp := range c.marketplaces {
+		if match(mp) {
+			return mp, true
+		}
+	}
+	return Marketplace{}, false
+}
+
+// Marketplaces return all the marketplaces in the catalog, sorted by the
+// 	country code.
+func (c *Catalog) Marketplaces() []Marketplace {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	marketplaces := append([]Marketplace{}, c.marketplaces...)
+	sort.Slice(marketplaces, func(i, j int) bool {
+		return marketplaces[i].CountryCode < marketplaces[j].CountryCode
+	})
+	return marketplaces
+}
+
+// Group return the marketplaces in the region group, ex: GroupEU.
+func (c *Catalog) Group(group string) []Marketplace {
+	marketplaces := []Marketplace{}
+	for _, mp := range c.Marketplaces() {
+		if mp.Group == group {
+			marketplaces = append(marketplaces, mp)
+		}
+	}
+	return marketplaces
+}
+
+// endPoints return the endpoints by the marketplace id, read by the lookups
+// 	as the fallback.
+func (c *Catalog) endPoints() map[string]string {
+	endPoints := map[string]string{}
+	for _, mp := range c.Marketplaces() {
+		endPoints[mp.ID] = mp.EndPoint
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.legacyEndPoints = endPoints
+	return endPoints
+}
+
+// ids return the marketplace ids by the country code, read by the lookups
+// 	as the fallback.
+func (c *Catalog) ids() map[string]string {
+	ids := map[string]string{}
+	for _, mp := range c.Marketplaces() {
+		ids[mp.CountryCode] = mp.ID
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.legacyIds = ids
+	return ids
+}
+
+// DefaultCatalog the catalog of the Amazon marketplaces, used by the clients.
+var DefaultCatalog = NewCatalog(
+	// North America.
+	Marketplace{"A2EUQ1WTGCTBG2", "CA", "amazon.ca", "mws.amazonservices.ca", "CAD", "en_CA", "ISO-8859-1", GroupNA},
+	Marketplace{"ATVPDKIKX0DER", "US", "amazon.com", "mws.amazonservices.com", "USD", "en_US", "ISO-8859-1", GroupNA},
+	Marketplace{"A1AM78C64UM0Y8", "MX", "amazon.com.mx", "mws.amazonservices.com.mx", "MXN", "es_MX", "ISO-8859-1", GroupNA},
+	Marketplace{"A2Q3Y263D00KWC", "BR", "amazon.com.br", "mws.amazonservices.com", "BRL", "pt_BR", "ISO-8859-1", GroupNA},
+	// Europe.
+	Marketplace{"A1PA6795UKMFR9", "DE", "amazon.de", "mws-eu.amazonservices.com", "EUR", "de_DE", "ISO-8859-1", GroupEU},
+	Marketplace{"A1RKKUPIHCS9HS", "ES", "amazon.es", "mws-eu.amazonservices.com", "EUR", "es_ES", "ISO-8859-1", GroupEU},
+	Marketplace{"A13V1IB3VIYZZH", "FR", "amazon.fr", "mws-eu.amazonservices.com", "EUR", "fr_FR", "ISO-8859-1", GroupEU},
+	Marketplace{"APJ6JRA9NG5V4", "IT", "amazon.it", "mws-eu.amazonservices.com", "EUR", "it_IT", "ISO-8859-1", GroupEU},
+	Marketplace{"A1F83G8C2ARO7P", "UK", "amazon.co.uk", "mws-eu.amazonservices.com", "GBP", "en_GB", "ISO-8859-1", GroupEU},
+	Marketplace{"A1805IZSGTT6HS", "NL", "amazon.nl", "mws-eu.amazonservices.com", "EUR", "nl_NL", "ISO-8859-1", GroupEU},
+	Marketplace{"A2NODRKZP88ZB9", "SE", "amazon.se", "mws-eu.amazonservices.com", "SEK", "sv_SE", "ISO-8859-1", GroupEU},
+	Marketplace{"A1C3SOZRARQ6R3", "PL", "amazon.pl", "mws-eu.amazonservices.com", "PLN", "pl_PL", "UTF-8", GroupEU},
+	Marketplace{"A33AVAJ2PDY3EV", "TR", "amazon.com.tr", "mws-eu.amazonservices.com", "TRY", "tr_TR", "UTF-8", GroupEU},
+	Marketplace{"A17E79C6D8DWNP", "SA", "amazon.sa", "mws-eu.amazonservices.com", "SAR", "ar_SA", "UTF-8", GroupEU},
+	Marketplace{"ARBP9OOSHTCHU", "EG", "amazon.eg", "mws-eu.amazonservices.com", "EGP", "ar_EG", "UTF-8", GroupEU},
+	Marketplace{"A2VIGQ35RCS4UG", "AE", "amazon.ae", "mws.amazonservices.ae", "AED", "en_AE", "UTF-8", GroupEU},
+	Marketplace{"A21TJRUUN4KGV", "IN", "amazon.in", "mws.amazonservices.in", "INR", "en_IN", "ISO-8859-1", GroupEU},
+	// Far East.
+	Marketplace{"A1VC38T7YXB528", "JP", "amazon.co.jp", "mws.amazonservices.jp", "JPY", "ja_JP", "Shift_JIS", GroupFE},
+	Marketplace{"A39IBJ37TRP1C6", "AU", "amazon.com.au", "mws.amazonservices.com.au", "AUD", "en_AU", "UTF-8", GroupFE},
+	Marketplace{"A19VAU5U5O7RUS", "SG", "amazon.sg", "mws-fe.amazonservices.com", "SGD", "en_SG", "UTF-8", GroupFE},
+	// China.
+	Marketplace{"AAHKV2X7AFYLW", "CN", "amazon.cn", "mws.amazonservices.com.cn", "CNY", "zh_CN", "UTF-16", GroupCN},
+)
+
+// LookupMarketplace find the marketplace in the DefaultCatalog by id,
+// 	country code or domain.
+func LookupMarketplace(key string) (Marketplace, bool) {
+	return DefaultCatalog.Lookup(key)
+}
+
+// RegisterMarketplace add the marketplace to the DefaultCatalog.
+func RegisterMarketplace(mp Marketplace) error {
+	return DefaultCatalog.Register(mp)
+}
+
+// RegisterEndPoint set a custom endpoint for the marketplace in the
+// 	DefaultCatalog, ex: point the US marketplace to a mock server.
+// The clients created after use the endpoint.
+func RegisterEndPoint(key, endPoint string) error {
+	return DefaultCatalog.RegisterEndPoint(key, endPoint)
+}
@@ -0,0 +1,137 @@
+package mws
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws/mock"
+)
+
+func TestCatalog_Lookup(t *testing.T) {
+	Convey("Look up the marketplace", t, func() {
+		for _, key := range []string{"A1F83G8C2ARO7P", "UK", "gb", "amazon.co.uk", "www.Amazon.co.uk"} {
+			mp, ok := LookupMarketplace(key)
+			So(ok, ShouldBeTrue)
+			So(mp.ID, ShouldEqual, "A1F83G8C2ARO7P")
+		}
+	})
+
+	Convey("Attributes of the marketplace", t, func() {
+		mp, ok := LookupMarketplace("BR")
+		So(ok, ShouldBeTrue)
+		So(mp, ShouldResemble, Marketplace{
+			ID:          "A2Q3Y263D00KWC",
+			CountryCode: "BR",
+			Domain:      "amazon.com.br",
+			EndPoint:    "mws.amazonservices.com",
+			Currency:    "BRL",
+			Locale:      "pt_BR",
+			Encoding:    "ISO-8859-1",
+			Group:       GroupNA,
+		})
+	})
+
+	Convey("Unknown marketplace", t, func() {
+		_, ok := LookupMarketplace("amazon.example")
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Marketplaces of the group", t, func() {
+		codes := []string{}
+		for _, mp := range DefaultCatalog.Group(GroupFE) {
+			codes = append(codes, mp.CountryCode)
+		}
+		So(codes, ShouldResemble, []string{"AU", "JP", "SG"})
+	})
+}
+
+func TestCatalog_Register(t *testing.T) {
+	catalog := NewCatalog(Marketplace{ID: "ATVPDKIKX0DER", CountryCode: "US", EndPoint: "mws.amazonservices.com"})
+
+	Convey("Register a marketplace", t, func() {
+		err := catalog.Register(Marketplace{ID: "TEST", CountryCode: "ZZ", EndPoint: "localhost"})
+		So(err, ShouldBeNil)
+		So(len(catalog.Marketplaces()), ShouldEqual, 2)
+	})
+
+	Convey("Register a marketplace without endpoint", t, func() {
+		err := catalog.Register(Marketplace{ID: "TEST", CountryCode: "ZZ"})
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Register a custom endpoint", t, func() {
+		So(catalog.RegisterEndPoint("US", "127.0.0.1:8443"), ShouldBeNil)
+		mp, _ := catalog.Lookup("ATVPDKIKX0DER")
+		So(mp.EndPoint, ShouldEqual, "127.0.0.1:8443")
+		So(len(catalog.Marketplaces()), ShouldEqual, 2)
+	})
+
+	Convey("Register a custom endpoint for unknown marketplace", t, func() {
+		err := catalog.RegisterEndPoint("Bad", "127.0.0.1:8443")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "Invalid marketplace: Bad")
+	})
+}
+
+func TestCatalog_Client(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.TimestampTolerance = 0
+	server.HandleAction("Test", "GetServiceStatus", func(req *mock.Request) *http.Response {
+		return mock.NewResponse(200, "<GetServiceStatusResponse/>")
+	})
+
+	RegisterMarketplace(Marketplace{ID: "MOCKMARKET", CountryCode: "ZZ", EndPoint: server.Host(), Encoding: "UTF-8"})
+
+	Convey("Client of the registered marketplace", t, func() {
+		config := testConfig()
+		config.Region = "ZZ"
+		client, err := NewClient(config, "V1", "Test")
+		So(err, ShouldBeNil)
+		So(client.MarketPlaceId, ShouldEqual, "MOCKMARKET")
+		So(Encoding(client.Region), ShouldEqual, "UTF-8")
+
+		client.Transport = mock.NoVerifyTransport()
+		resp, err := client.SendRequest(Parameters{"Action": "GetServiceStatus"})
+		So(err, ShouldBeNil)
+		So(resp.Error, ShouldBeNil)
+		resp.Close()
+	})
+}
+
+func TestCatalog_LegacyMaps(t *testing.T) {
+	Convey("Read the changes of EndPoints", t, func() {
+		EndPoints["ATVPDKIKX0DER"] = "127.0.0.1:8443"
+		defer func() { EndPoints["ATVPDKIKX0DER"] = "mws.amazonservices.com" }()
+
+		mp, ok := LookupMarketplace("US")
+		So(ok, ShouldBeTrue)
+		So(mp.EndPoint, ShouldEqual, "127.0.0.1:8443")
+
+		client, err := NewClient(testConfig(), "V1", "Test")
+		So(err, ShouldBeNil)
+		So(client.Host, ShouldEqual, "127.0.0.1:8443")
+	})
+
+	Convey("Read the marketplaces added to the maps", t, func() {
+		MarketPlaceIds["ZY"] = "LEGACYMARKET"
+		EndPoints["LEGACYMARKET"] = "127.0.0.1:8443"
+		defer delete(MarketPlaceIds, "ZY")
+		defer delete(EndPoints, "LEGACYMARKET")
+
+		config := testConfig()
+		config.Region = "ZY"
+		client, err := NewClient(config, "V1", "Test")
+		So(err, ShouldBeNil)
+		So(client.MarketPlaceId, ShouldEqual, "LEGACYMARKET")
+		So(client.Host, ShouldEqual, "127.0.0.1:8443")
+	})
+
+	Convey("Add the marketplaces registered to the maps", t, func() {
+		err := RegisterMarketplace(Marketplace{ID: "REGMARKET", CountryCode: "ZX", EndPoint: "127.0.0.1:9443"})
+		So(err, ShouldBeNil)
+		So(EndPoints["REGMARKET"], ShouldEqual, "127.0.0.1:9443")
+		So(MarketPlaceIds["ZX"], ShouldEqual, "REGMARKET")
+	})
+}
@@ -2,11 +2,15 @@ package mws
 
 import (
 	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Values is a url.Values for custom encoding.
@@ -24,14 +28,36 @@ func (params Values) Encode() string {
 	return strings.Replace(params.Values.Encode(), "+", "%20", -1)
 }
 
+// Body is the document send as the request body, ex: the feed of SubmitFeed.
+// For request with body, the signed parameters are send in the query string.
+type Body struct {
+	// Content type of the document, default to application/octet-stream.
+	ContentType string
+	// The document.
+	Content []byte
+}
+
+// MD5 return the base64 encoded md5 of the content.
+func (body Body) MD5() string {
+	sum := md5.Sum(body.Content)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
 // Client the basic client handle request send to API endpoint.
 type Client struct {
 	// The api host for the region.
 	Host string
+	// Scheme of the endpoint, default to https.
+	Scheme string
+	// Path prefix of the endpoint, ex: the path of a recording proxy.
+	BasePath string
 	// Region of the marketplace in two character.
 	Region string
-	// Marketplace identitier for the region.
+	// Marketplace identitier for the region, or the first marketplace set in
+	// 	the config.
 	MarketPlaceId string
+	// Marketplaces set in the config, all share the Host.
+	Marketplaces []string
 	// The API version.
 	Version string
 	// The API name.
@@ -43,6 +69,22 @@ type Client struct {
 	// Credential for requests.
 	accessKey string
 	secretKey string
+	// Credentials to refresh the credential, nil to use the credential above.
+	credentials *Credentials
+	// Pool handed out the client, the seller authorization is checked on
+	// 	each request.
+	pool *Pool
+	// Retry policy for failed requests, no retry if nil.
+	Retry *RetryPolicy
+	// Throttler to limit the requests by the quota, no limit if nil.
+	// A throttler can be shared by multiple clients.
+	Throttler *Throttler
+	// Middlewares intercept the requests, the first one is the outermost.
+	// The retry and throttle are applied inside them, see Use.
+	Middlewares []Middleware
+	// Logger to log the requests with the secrets redacted, no log if nil.
+	// See LoggingMiddleware.
+	Logger Logger
 
 	*http.Client
 }
@@ -56,7 +98,21 @@ func NewClient(config Config, version, name string) (*Client, error) {
 		return nil, fmt.Errorf("No seller id provided")
 	}
 
+	if len(config.Marketplaces) > 50 {
+		return nil, fmt.Errorf("Too many marketplaces: %v, max 50", len(config.Marketplaces))
+	}
+	endPoint, mError := MarketPlacesEndPoint(config.Marketplaces)
+	if mError != nil {
+		return nil, mError
+	}
+
 	region := config.Region
+	if region == "" && len(config.Marketplaces) > 0 {
+		region, mError = MarketPlaceRegion(config.Marketplaces[0])
+		if mError != nil {
+			return nil, mError
+		}
+	}
 	if region == "" {
 		region = "US"
 	}
@@ -65,10 +121,28 @@ func NewClient(config Config, version, name string) (*Client, error) {
 	if mError != nil {
 		return nil, mError
 	}
+	if endPoint != "" && endPoint != marketPlace.EndPoint {
+		return nil, fmt.Errorf("%w: region %v (%v) and %v (%v)", ErrMixedMarketplaces, region, marketPlace.EndPoint, config.Marketplaces[0], endPoint)
+	}
+	if len(config.Marketplaces) > 0 {
+		marketPlace.Id = config.Marketplaces[0]
+	}
+
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
 
-	credential := config.Credential()
-	if credential.AccessKey == "" || credential.SecretKey == "" {
-		return nil, fmt.Errorf("Can't find mws credential information")
+	// The credential from a custom provider is retrieved lazily, others
+	// 	are checked now.
+	credentials := NewCredentials(config.CredentialProvider())
+	credential := Credential{}
+	if config.Credentials == nil {
+		var err error
+		credential, err = credentials.Get()
+		if err != nil {
+			return nil, fmt.Errorf("Can't find mws credential information: %w", err)
+		}
 	}
 
 	base := Client{
@@ -76,20 +150,90 @@ func NewClient(config Config, version, name string) (*Client, error) {
 		AuthToken:     config.AuthToken,
 		Region:        region,
 		MarketPlaceId: marketPlace.Id,
+		Marketplaces:  config.Marketplaces,
 		Host:          marketPlace.EndPoint,
 		Version:       version,
 		Name:          name,
 		accessKey:     credential.AccessKey,
 		secretKey:     credential.SecretKey,
-		Client:        new(http.Client),
+		credentials:   credentials,
+		Client:        httpClient,
+	}
+
+	if config.Endpoint != "" {
+		endPoint, err := parseEndpoint(config.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		base.Scheme = endPoint.Scheme
+		base.Host = endPoint.Host
+		base.BasePath = strings.TrimRight(endPoint.Path, "/")
 	}
 
 	return &base, nil
 }
 
+// newHTTPClient return the http client set in the config, or a new one with
+// 	the transport in the config.
+func newHTTPClient(config Config) (*http.Client, error) {
+	if config.HTTPClient != nil && config.Transport != nil {
+		return nil, fmt.Errorf("HTTPClient and Transport can't be both set")
+	}
+	if config.HTTPClient != nil {
+		return config.HTTPClient, nil
+	}
+	return &http.Client{Transport: config.Transport}, nil
+}
+
+// parseEndpoint parse and validate the endpoint url.
+func parseEndpoint(endPoint string) (*url.URL, error) {
+	u, err := url.Parse(endPoint)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid endpoint %v: %w", endPoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("Invalid endpoint %v: scheme must be http or https", endPoint)
+	}
+	if u.Host == "" || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("Invalid endpoint %v: must be a url with host and optional path only", endPoint)
+	}
+	return u, nil
+}
+
+// MarketplaceIds return the marketplaces set in the config, or the
+// 	marketplace of the region if not set.
+func (base Client) MarketplaceIds() []string {
+	if len(base.Marketplaces) > 0 {
+		return base.Marketplaces
+	}
+	return []string{base.MarketPlaceId}
+}
+
+// ForMarketplace return a copy of the client for the marketplace, for the
+// 	operations accept one marketplace, ex: the Products operations.
+// The marketplace must be one of the MarketplaceIds.
+func (base Client) ForMarketplace(id string) (*Client, error) {
+	for _, marketPlaceId := range base.MarketplaceIds() {
+		if marketPlaceId == id {
+			base.MarketPlaceId = id
+			return &base, nil
+		}
+	}
+	return nil, MarketPlaceError{"marketplace id", id}
+}
+
+// Use add the middlewares to the client, ex: logging or metrics.
+// The middlewares are applied in order, outside the Retry and Throttler of
+// 	the client, so they see the final response of the request. To intercept
+// 	each attempt, leave Retry and Throttler nil and use the RetryMiddleware
+// 	and ThrottleMiddleware instead.
+func (base *Client) Use(middlewares ...Middleware) {
+	base.Middlewares = append(base.Middlewares, middlewares...)
+}
+
 // Path generate the url path for the api endpoint.
 func (base Client) Path() string {
-	path := ""
+	path := base.BasePath
 	if base.Name != "" {
 		path += "/" + base.Name
 	}
@@ -99,9 +243,14 @@ func (base Client) Path() string {
 	return path
 }
 
-// EndPoint generate the endpoint for the request by combinding the host and path.
+// EndPoint generate the endpoint for the request by combinding the scheme,
+// 	host and path.
 func (base Client) EndPoint() string {
-	return "https://" + base.Host + base.Path()
+	scheme := base.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	return scheme + "://" + base.Host + base.Path()
 }
 
 // SignatureMethod return the HmacSHA256 signature method string.
@@ -116,28 +265,134 @@ func (base Client) SignatureVersion() string {
 
 // SendRequest accept a structured params and send the request to the API.
 func (base Client) SendRequest(structuredParams Parameters) (*Response, error) {
-	request, err := base.buildRequest(structuredParams)
+	return base.SendRequestContext(context.Background(), structuredParams)
+}
+
+// SendRequestContext send the request to the API with the context.
+// Once the context is canceled or expired, the request will be aborted,
+// 	include the reading of the response body.
+// If the client has retry policy, the request will be re-signed and sent
+// 	again when the response has retryable error.
+// If the client has throttler, each attempt will wait until the quota allow.
+// If MWS return InvalidAccessKeyId, the credential will be retrieved again
+// 	from the provider and the request sent once more.
+func (base Client) SendRequestContext(ctx context.Context, structuredParams Parameters) (*Response, error) {
+	return base.sendRequest(ctx, structuredParams)
+}
+
+// SendBodyRequest send the request with the body to the API.
+// The parameters are send in the query string, and the body is send with
+// 	the Content-MD5 header.
+func (base Client) SendBodyRequest(structuredParams Parameters, body Body) (*Response, error) {
+	return base.SendBodyRequestContext(context.Background(), structuredParams, body)
+}
+
+// SendBodyRequestContext is the context version of SendBodyRequest.
+func (base Client) SendBodyRequestContext(ctx context.Context, structuredParams Parameters, body Body) (*Response, error) {
+	return base.sendRequest(ctx, structuredParams, body)
+}
+
+// sendRequest send the request through the middlewares, the retry policy
+// 	and the throttler.
+// The optional body is send as the request body.
+func (base Client) sendRequest(ctx context.Context, structuredParams Parameters, body ...Body) (*Response, error) {
+	action, _ := structuredParams["Action"].(string)
+	req := &Request{
+		Name:          base.Name,
+		Version:       base.Version,
+		Action:        action,
+		SellerId:      base.SellerId,
+		MarketplaceId: base.MarketPlaceId,
+		Params:        structuredParams,
+		Header:        http.Header{},
+	}
+	if len(body) > 0 {
+		req.Body = &body[0]
+	}
+
+	return base.handler()(ctx, req)
+}
+
+// handler chain the middlewares of the client, from the outermost:
+// 	the Middlewares, logging, retry, credential refresh, throttle, and send.
+func (base Client) handler() Handler {
+	middlewares := append([]Middleware{}, base.Middlewares...)
+	if base.Logger != nil {
+		middlewares = append(middlewares, LoggingMiddleware(base.Logger))
+	}
+	middlewares = append(middlewares, RetryMiddleware(base.Retry))
+	if base.credentials != nil {
+		middlewares = append(middlewares, refreshMiddleware(base.credentials))
+	}
+	if base.Throttler != nil {
+		middlewares = append(middlewares, ThrottleMiddleware(base.Throttler))
+	}
+	return Chain(middlewares...)(base.send)
+}
+
+// send sign and send the request once, the attempt is recorded in the request.
+func (base Client) send(ctx context.Context, req *Request) (*Response, error) {
+	if base.credentials != nil {
+		credential, err := base.credentials.Get()
+		if err != nil {
+			return nil, err
+		}
+		base.accessKey, base.secretKey = credential.AccessKey, credential.SecretKey
+	}
+	if base.pool != nil {
+		authToken, err := base.pool.authToken(base.SellerId)
+		if err != nil {
+			return nil, err
+		}
+		base.AuthToken = authToken
+	}
+
+	body := []Body{}
+	if req.Body != nil {
+		body = append(body, *req.Body)
+	}
+	request, err := base.buildRequest(req.Params, body...)
 	if err != nil {
 		return nil, err
 	}
+	for k, v := range req.Header {
+		request.Header[k] = v
+	}
+	req.HTTPRequest = request.WithContext(ctx)
 
-	resp, err := base.Client.Do(request)
+	attempt := Attempt{Number: len(req.Attempts) + 1, Time: time.Now(), Delay: req.delay}
+	req.delay = 0
+	resp, err := base.Client.Do(req.HTTPRequest)
 	if err != nil {
+		attempt.Err = err
+		req.Attempts = append(req.Attempts, attempt)
 		return nil, err
 	}
+	resp.Body = &contextReader{ctx: ctx, ReadCloser: resp.Body}
 
-	return NewResponse(resp), nil
+	response := NewResponse(resp)
+	attempt.StatusCode = response.StatusCode
+	attempt.Err = response.Error
+	req.Attempts = append(req.Attempts, attempt)
+	response.Attempts = req.Attempts
+	return response, nil
 }
 
 // buildRequest prepare the requet to send to the api.
 // The method will create a post request with encoded body of signed parameters.
-func (base Client) buildRequest(structuredParams Parameters) (*http.Request, error) {
+// If the body is passed in, the signed parameters will be in the query string,
+// 	and the body will be the request body with Content-MD5 header.
+func (base Client) buildRequest(structuredParams Parameters, body ...Body) (*http.Request, error) {
 	params, err := structuredParams.Normalize()
 	if err != nil {
 		return nil, err
 	}
 
 	encodedParams := base.signQuery(params).Encode()
+	if len(body) > 0 {
+		return base.buildBodyRequest(encodedParams, body[0])
+	}
+
 	req, err := http.NewRequest(
 		"POST",
 		base.EndPoint(),
@@ -155,6 +410,32 @@ func (base Client) buildRequest(structuredParams Parameters) (*http.Request, err
 	return req, nil
 }
 
+// buildBodyRequest create a post request with the signed parameters in the
+// query string and the body as request body.
+func (base Client) buildBodyRequest(encodedParams string, body Body) (*http.Request, error) {
+	req, err := http.NewRequest(
+		"POST",
+		base.EndPoint()+"?"+encodedParams,
+		bytes.NewReader(body.Content),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := body.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	// Add content headers.
+	req.Header.Add("Content-Type", contentType)
+	req.Header.Add("Content-Length", strconv.Itoa(len(body.Content)))
+	req.Header.Add("Content-MD5", body.MD5())
+
+	return req, nil
+}
+
 // signQuery generate the signature and add the signature to the http parameters.
 func (base Client) signQuery(params Values) Values {
 	// Add client info to the query params.
@@ -1,7 +1,10 @@
 package mws
 
 import (
+	"context"
+	"errors"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"os"
 	"strconv"
@@ -89,6 +92,71 @@ func TestNewClient(t *testing.T) {
 		})
 	})
 
+	Convey("When Marketplaces are provide", t, func() {
+		tconfig := testConfig()
+		tconfig.Region = ""
+		tconfig.Marketplaces = []string{"A1PA6795UKMFR9", "A13V1IB3VIYZZH"}
+
+		client, err := NewClient(tconfig, "V1", "Test")
+
+		Convey("No error returned", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Region and endpoint of the first marketplace used", func() {
+			So(client.Region, ShouldEqual, "DE")
+			So(client.MarketPlaceId, ShouldEqual, "A1PA6795UKMFR9")
+			So(client.Host, ShouldEqual, "mws-eu.amazonservices.com")
+			So(client.MarketplaceIds(), ShouldResemble, tconfig.Marketplaces)
+		})
+
+		Convey("Client for a marketplace in the list", func() {
+			fr, err := client.ForMarketplace("A13V1IB3VIYZZH")
+			So(err, ShouldBeNil)
+			So(fr.MarketPlaceId, ShouldEqual, "A13V1IB3VIYZZH")
+			So(client.MarketPlaceId, ShouldEqual, "A1PA6795UKMFR9")
+		})
+
+		Convey("Client for a marketplace not in the list", func() {
+			_, err := client.ForMarketplace("ATVPDKIKX0DER")
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "Invalid marketplace id: ATVPDKIKX0DER")
+		})
+	})
+
+	Convey("When Marketplaces from different regions are provide", t, func() {
+		tconfig := testConfig()
+		tconfig.Region = ""
+		tconfig.Marketplaces = []string{"A1PA6795UKMFR9", "ATVPDKIKX0DER"}
+
+		client, err := NewClient(tconfig, "V1", "Test")
+
+		Convey("Mixed marketplaces error returned", func() {
+			So(client, ShouldBeNil)
+			So(errors.Is(err, ErrMixedMarketplaces), ShouldBeTrue)
+		})
+	})
+
+	Convey("When Marketplaces not in the region are provide", t, func() {
+		tconfig := testConfig()
+		tconfig.Marketplaces = []string{"A1PA6795UKMFR9"}
+
+		client, err := NewClient(tconfig, "V1", "Test")
+
+		Convey("Mixed marketplaces error returned", func() {
+			So(client, ShouldBeNil)
+			So(errors.Is(err, ErrMixedMarketplaces), ShouldBeTrue)
+		})
+	})
+
+	Convey("When Marketplaces not provide", t, func() {
+		client, _ := NewClient(testConfig(), "V1", "Test")
+
+		Convey("Marketplace of the region used", func() {
+			So(client.MarketplaceIds(), ShouldResemble, []string{"ATVPDKIKX0DER"})
+		})
+	})
+
 	Convey("When Seller Id is not provide", t, func() {
 		tconfig := testConfig()
 		tconfig.SellerId = ""
@@ -138,7 +206,8 @@ func TestNewClient(t *testing.T) {
 
 			Convey("Credential error returned", func() {
 				So(err, ShouldNotBeNil)
-				So(err.Error(), ShouldEqual, "Can't find mws credential information")
+				So(err.Error(), ShouldStartWith, "Can't find mws credential information: ")
+				So(errors.Is(err, ErrCredentialNotFound), ShouldBeTrue)
 			})
 		})
 
@@ -227,6 +296,83 @@ func TestClient_EndPoint(t *testing.T) {
 	})
 }
 
+func TestClient_EndPoint_custom(t *testing.T) {
+	Convey("Endpoint with http scheme and path prefix", t, func() {
+		config := testConfig()
+		config.Endpoint = "http://localhost:8080/proxy/"
+		client, err := NewClient(config, testVersion, testClientName)
+		So(err, ShouldBeNil)
+		So(client.EndPoint(), ShouldEqual, "http://localhost:8080/proxy/Products/2011-10-01")
+		So(client.MarketPlaceId, ShouldEqual, "ATVPDKIKX0DER")
+
+		Convey("Signed by the host and path of the endpoint", func() {
+			stringToSign := client.generateStringToSignV2(testParams.values)
+			So(stringToSign, ShouldStartWith, "POST\nlocalhost:8080\n/proxy/Products/2011-10-01\n")
+		})
+	})
+
+	Convey("Invalid endpoint", t, func() {
+		for _, endPoint := range []string{"localhost:8080", "ftp://localhost", "http://", "http://localhost?a=b"} {
+			config := testConfig()
+			config.Endpoint = endPoint
+			client, err := NewClient(config, testVersion, testClientName)
+			So(client, ShouldBeNil)
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("Both HTTPClient and Transport are set", t, func() {
+		config := testConfig()
+		config.HTTPClient = new(http.Client)
+		config.Transport = mock.NoVerifyTransport()
+		client, err := NewClient(config, testVersion, testClientName)
+		So(client, ShouldBeNil)
+		So(err.Error(), ShouldEqual, "HTTPClient and Transport can't be both set")
+	})
+
+	Convey("HTTPClient is set", t, func() {
+		config := testConfig()
+		config.HTTPClient = new(http.Client)
+		client, _ := NewClient(config, testVersion, testClientName)
+		So(client.Client, ShouldEqual, config.HTTPClient)
+	})
+}
+
+func TestClient_SendRequest_endpoint(t *testing.T) {
+	Convey("Send to a http server", t, func() {
+		server := mock.NewHTTPServer()
+		defer server.Close()
+		server.TimestampTolerance = 0
+		server.SetCredential(testAccessKey, testSecretKey)
+
+		config := testConfig()
+		config.Endpoint = server.URL
+		client, _ := NewClient(config, "2011-10-01", "Products")
+
+		resp, err := client.SendRequest(Parameters{"Action": "GetServiceStatus"})
+		So(err, ShouldBeNil)
+		So(resp.Error, ShouldBeNil)
+		resp.Close()
+	})
+
+	Convey("Send to a https server with the transport", t, func() {
+		server := mock.NewServer()
+		defer server.Close()
+		server.TimestampTolerance = 0
+		server.SetCredential(testAccessKey, testSecretKey)
+
+		config := testConfig()
+		config.Endpoint = server.URL
+		config.Transport = mock.NoVerifyTransport()
+		client, _ := NewClient(config, "2011-10-01", "Products")
+
+		resp, err := client.SendRequest(Parameters{"Action": "GetServiceStatus"})
+		So(err, ShouldBeNil)
+		So(resp.Error, ShouldBeNil)
+		resp.Close()
+	})
+}
+
 func TestClient_SignatureMethod(t *testing.T) {
 	Convey("Signature method HmacSHA256 returned", t, func() {
 		client, _ := NewClient(testConfig(), testVersion, testClientName)
@@ -311,6 +457,49 @@ func TestClient_SendRequest(t *testing.T) {
 	})
 }
 
+func TestClient_SendRequestContext(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.SetResponse(mock.NewResponse(200, "Hello, client"))
+
+	client, _ := NewClient(testConfig(), testVersion, testClientName)
+	client.Host = server.Host()
+	client.Transport = mock.NoVerifyTransport()
+
+	Convey("When context is canceled before sending", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		resp, err := client.SendRequestContext(ctx, testParams.params)
+
+		Convey("Context error returned", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, context.Canceled.Error())
+		})
+
+		Convey("Response is nil", func() {
+			So(resp, ShouldBeNil)
+		})
+	})
+
+	Convey("When context is canceled before reading body", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		resp, err := client.SendRequestContext(ctx, testParams.params)
+		defer resp.Close()
+		cancel()
+		_, readErr := ioutil.ReadAll(resp.Body)
+
+		Convey("SendRequestContext not return error", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Reading body return context error", func() {
+			So(readErr, ShouldEqual, context.Canceled)
+		})
+	})
+}
+
 func TestClient_buildRequest(t *testing.T) {
 	now = func() string { return testTimestamp }
 
@@ -364,6 +553,56 @@ func TestClient_buildRequest(t *testing.T) {
 	})
 }
 
+func TestClient_buildRequest_body(t *testing.T) {
+	now = func() string { return testTimestamp }
+
+	Convey("When create request with body", t, func() {
+		client, _ := NewClient(testConfig(), testVersion, testClientName)
+		values, _ := testParams.params.Normalize()
+		expectedSignedQuery := client.signQuery(values).Encode()
+		body := Body{ContentType: "text/xml", Content: []byte("<Feed/>")}
+
+		request, err := client.buildRequest(testParams.params, body)
+
+		Convey("Error is nil", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Request has signed params in query string", func() {
+			So(request.URL.RawQuery, ShouldEqual, expectedSignedQuery)
+			So(request.URL.Path, ShouldEqual, client.Path())
+		})
+
+		Convey("Request body is the content", func() {
+			content, _ := ioutil.ReadAll(request.Body)
+			So(string(content), ShouldEqual, "<Feed/>")
+		})
+
+		Convey("Request has content headers", func() {
+			So(request.Header.Get("Content-Type"), ShouldEqual, "text/xml")
+			So(request.Header.Get("Content-Length"), ShouldEqual, "7")
+			So(request.Header.Get("Content-MD5"), ShouldEqual, body.MD5())
+		})
+	})
+
+	Convey("When content type not set", t, func() {
+		client, _ := NewClient(testConfig(), testVersion, testClientName)
+
+		request, _ := client.buildRequest(testParams.params, Body{Content: []byte("a")})
+
+		Convey("Content type is octet stream", func() {
+			So(request.Header.Get("Content-Type"), ShouldEqual, "application/octet-stream")
+		})
+	})
+}
+
+func TestBody_MD5(t *testing.T) {
+	Convey("Return base64 encoded md5 of the content", t, func() {
+		body := Body{Content: []byte("Hello, world")}
+		So(body.MD5(), ShouldEqual, "vG5vFrigd+9fvI1Z0LkxuQ==")
+	})
+}
+
 func TestClient_signQuery(t *testing.T) {
 	now = func() string { return testTimestamp }
 	config := testConfig()
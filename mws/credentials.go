@@ -0,0 +1,301 @@
+package mws
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ErrCredentialNotFound is returned by the providers when the credential is
+// 	not available from the source.
+var ErrCredentialNotFound = errors.New("Credential not found")
+
+// CredentialProvider provide the credential to access the API.
+type CredentialProvider interface {
+	// Retrieve return the credential, ErrCredentialNotFound if the provider
+	// 	don't have one.
+	Retrieve() (Credential, error)
+}
+
+// StaticProvider provide the credential set in it.
+type StaticProvider struct {
+	Credential
+}
+
+// Retrieve return the credential set.
+func (p StaticProvider) Retrieve() (Credential, error) {
+	if p.AccessKey == "" || p.SecretKey == "" {
+		return Credential{}, ErrCredentialNotFound
+	}
+	return p.Credential, nil
+}
+
+// EnvProvider provide the credential from the env variables,
+// 	AWS_ACCESS_KEY and AWS_SECRET_KEY, or the standard AWS_ACCESS_KEY_ID and
+// 	AWS_SECRET_ACCESS_KEY.
+type EnvProvider struct{}
+
+// Retrieve return the credential from the env variables.
+func (p EnvProvider) Retrieve() (Credential, error) {
+	credential := GetCredential()
+	if credential.AccessKey == "" || credential.SecretKey == "" {
+		credential = Credential{
+			AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		}
+	}
+	return StaticProvider{credential}.Retrieve()
+}
+
+// SharedFileProvider provide the credential from the profile in the shared
+// 	credentials file in INI format, ex:
+// 	[default]
+// 	aws_access_key_id = AKey
+// 	aws_secret_access_key = SKey
+type SharedFileProvider struct {
+	// Path of the file. Default to the AWS_SHARED_CREDENTIALS_FILE env
+	// 	variable, or ~/.aws/credentials.
+	Filename string
+	// Name of the profile. Default to the AWS_PROFILE env variable, or default.
+	Profile string
+}
+
+// Retrieve return the credential of the profile.
+func (p SharedFileProvider) Retrieve() (Credential, error) {
+	filename := p.Filename
+	if filename == "" {
+		filename = os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	}
+	if filename == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return Credential{}, ErrCredentialNotFound
+		}
+		filename = filepath.Join(home, ".aws", "credentials")
+	}
+
+	profile := p.Profile
+	if profile == "" {
+		profile = os.Getenv("AWS_PROFILE")
+	}
+	if profile == "" {
+		profile = "default"
+	}
+
+	data, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return Credential{}, ErrCredentialNotFound
+	}
+	if err != nil {
+		return Credential{}, err
+	}
+
+	values, ok := parseINISection(data, profile)
+	if !ok {
+		return Credential{}, fmt.Errorf("%w: no profile %v in %v", ErrCredentialNotFound, profile, filename)
+	}
+	return StaticProvider{Credential{
+		AccessKey: values["aws_access_key_id"],
+		SecretKey: values["aws_secret_access_key"],
+	}}.Retrieve()
+}
+
+// parseINISection return the key values in the section of the INI data.
+// Both [name] and [profile name] sections are matched.
+func parseINISection(data []byte, name string) (map[string]string, bool) {
+	values := map[string]string{}
+	found, inSection := false, false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section := strings.TrimSpace(line[1 : len(line)-1])
+			section = strings.TrimSpace(strings.TrimPrefix(section, "profile "))
+			inSection = section == name
+			found = found || inSection
+			continue
+		}
+
+		if i := strings.Index(line, "="); i > 0 && inSection {
+			values[strings.TrimSpace(line[:i])] = strings.TrimSpace(line[i+1:])
+		}
+	}
+	return values, found
+}
+
+// FileProvider provide the credential from a JSON or YAML secrets file,
+// 	the format is decided by the extension, .json, .yaml or .yml. Ex:
+// 	{"AccessKey": "AKey", "SecretKey": "SKey"}
+type FileProvider struct {
+	// Path of the file.
+	Filename string
+}
+
+// Retrieve return the credential in the file.
+func (p FileProvider) Retrieve() (Credential, error) {
+	data, err := ioutil.ReadFile(p.Filename)
+	if os.IsNotExist(err) {
+		return Credential{}, ErrCredentialNotFound
+	}
+	if err != nil {
+		return Credential{}, err
+	}
+
+	secrets := struct {
+		AccessKey string `json:"AccessKey" yaml:"AccessKey"`
+		SecretKey string `json:"SecretKey" yaml:"SecretKey"`
+	}{}
+	switch strings.ToLower(filepath.Ext(p.Filename)) {
+	case ".json":
+		err = json.Unmarshal(data, &secrets)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &secrets)
+	default:
+		err = fmt.Errorf("Unsupported secrets file format %v", filepath.Ext(p.Filename))
+	}
+	if err != nil {
+		return Credential{}, fmt.Errorf("Invalid secrets file %v: %w", p.Filename, err)
+	}
+
+	return StaticProvider{Credential{AccessKey: secrets.AccessKey, SecretKey: secrets.SecretKey}}.Retrieve()
+}
+
+// ProcessProvider provide the credential from the output of a command.
+// The command should print the credential in the AWS credential_process
+// 	format, the Expiration is optional:
+// 	{"Version": 1, "AccessKeyId": "AKey", "SecretAccessKey": "SKey", "Expiration": "2017-01-01T00:00:00Z"}
+type ProcessProvider struct {
+	// The command and its arguments.
+	Command []string
+	// Max time the command can run, the command is killed after it.
+	// Default to 1 minute.
+	Timeout time.Duration
+}
+
+// defaultProcessTimeout the max time the credential process can run if not set.
+const defaultProcessTimeout = time.Minute
+
+// Retrieve run the command and return the credential in the output.
+func (p ProcessProvider) Retrieve() (Credential, error) {
+	if len(p.Command) == 0 {
+		return Credential{}, ErrCredentialNotFound
+	}
+
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = defaultProcessTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Command[0], p.Command[1:]...)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if ctx.Err() != nil {
+		return Credential{}, fmt.Errorf("Credential process %v timed out after %v: %w", p.Command[0], timeout, ctx.Err())
+	}
+	if err != nil {
+		return Credential{}, fmt.Errorf("Credential process %v failed: %w: %v", p.Command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	result := struct {
+		Version         int
+		AccessKeyId     string
+		SecretAccessKey string
+		Expiration      string
+	}{}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return Credential{}, fmt.Errorf("Invalid output of credential process %v: %w", p.Command[0], err)
+	}
+
+	credential := Credential{AccessKey: result.AccessKeyId, SecretKey: result.SecretAccessKey}
+	if result.Expiration != "" {
+		credential.Expires, err = time.Parse(time.RFC3339, result.Expiration)
+		if err != nil {
+			return Credential{}, fmt.Errorf("Invalid expiration of credential process %v: %w", p.Command[0], err)
+		}
+	}
+	return StaticProvider{credential}.Retrieve()
+}
+
+// ChainProvider provide the credential from the first provider has one.
+type ChainProvider []CredentialProvider
+
+// NewChainProvider create a chain of the providers.
+func NewChainProvider(providers ...CredentialProvider) ChainProvider {
+	return ChainProvider(providers)
+}
+
+// Retrieve return the credential of the first provider has one.
+// Errors other than ErrCredentialNotFound stop the chain.
+func (chain ChainProvider) Retrieve() (Credential, error) {
+	for _, provider := range chain {
+		credential, err := provider.Retrieve()
+		if errors.Is(err, ErrCredentialNotFound) {
+			continue
+		}
+		return credential, err
+	}
+	return Credential{}, ErrCredentialNotFound
+}
+
+// Credentials cache the credential retrieved from the provider.
+// The credential is retrieved on first use, and again once expired or
+// 	expired manually by Expire, ex: when MWS return InvalidAccessKeyId.
+// Safe for concurrent use.
+type Credentials struct {
+	provider CredentialProvider
+
+	mu         sync.Mutex
+	credential Credential
+	retrieved  bool
+}
+
+// NewCredentials create the credentials of the provider.
+func NewCredentials(provider CredentialProvider) *Credentials {
+	return &Credentials{provider: provider}
+}
+
+// Get return the cached credential, retrieve it from the provider if not
+// 	retrieved or expired.
+func (c *Credentials) Get() (Credential, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.retrieved && (c.credential.Expires.IsZero() || time.Now().Before(c.credential.Expires)) {
+		return c.credential, nil
+	}
+
+	credential, err := c.provider.Retrieve()
+	if err != nil {
+		return Credential{}, err
+	}
+	c.credential, c.retrieved = credential, true
+	return credential, nil
+}
+
+// Expire expire the cached credential, the credential will be retrieved
+// 	again on next Get.
+func (c *Credentials) Expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.retrieved = false
+}
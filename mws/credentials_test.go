@@ -0,0 +1,241 @@
+package mws
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws/mock"
+)
+
+func TestEnvProvider(t *testing.T) {
+	Convey("Retrieve the credential from env variables", t, func() {
+		os.Unsetenv("AWS_ACCESS_KEY")
+		os.Unsetenv("AWS_SECRET_KEY")
+		os.Setenv("AWS_ACCESS_KEY_ID", "id_key")
+		os.Setenv("AWS_SECRET_ACCESS_KEY", "secret_key")
+		defer os.Unsetenv("AWS_ACCESS_KEY_ID")
+		defer os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+
+		credential, err := EnvProvider{}.Retrieve()
+		So(err, ShouldBeNil)
+		So(credential, ShouldResemble, Credential{AccessKey: "id_key", SecretKey: "secret_key"})
+
+		Convey("AWS_ACCESS_KEY and AWS_SECRET_KEY take precedence", func() {
+			os.Setenv("AWS_ACCESS_KEY", "a_key")
+			os.Setenv("AWS_SECRET_KEY", "s_key")
+			defer os.Unsetenv("AWS_ACCESS_KEY")
+			defer os.Unsetenv("AWS_SECRET_KEY")
+
+			credential, err := EnvProvider{}.Retrieve()
+			So(err, ShouldBeNil)
+			So(credential.AccessKey, ShouldEqual, "a_key")
+		})
+	})
+
+	Convey("Not found without env variables", t, func() {
+		_, err := EnvProvider{}.Retrieve()
+		So(err, ShouldEqual, ErrCredentialNotFound)
+	})
+}
+
+func TestSharedFileProvider(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "credentials")
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "credentials")
+	ioutil.WriteFile(filename, []byte(`
+# Comment
+[default]
+aws_access_key_id = default_key
+aws_secret_access_key = default_secret
+
+[profile seller]
+aws_access_key_id=seller_key
+aws_secret_access_key=seller_secret
+`), 0600)
+
+	Convey("Retrieve the credential of the default profile", t, func() {
+		credential, err := SharedFileProvider{Filename: filename}.Retrieve()
+		So(err, ShouldBeNil)
+		So(credential, ShouldResemble, Credential{AccessKey: "default_key", SecretKey: "default_secret"})
+	})
+
+	Convey("Retrieve the credential of the named profile", t, func() {
+		credential, err := SharedFileProvider{Filename: filename, Profile: "seller"}.Retrieve()
+		So(err, ShouldBeNil)
+		So(credential, ShouldResemble, Credential{AccessKey: "seller_key", SecretKey: "seller_secret"})
+	})
+
+	Convey("Not found for unknown profile or file", t, func() {
+		_, err := SharedFileProvider{Filename: filename, Profile: "unknown"}.Retrieve()
+		So(errors.Is(err, ErrCredentialNotFound), ShouldBeTrue)
+
+		_, err = SharedFileProvider{Filename: filepath.Join(dir, "unknown")}.Retrieve()
+		So(errors.Is(err, ErrCredentialNotFound), ShouldBeTrue)
+	})
+}
+
+func TestFileProvider(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "credentials")
+	defer os.RemoveAll(dir)
+
+	Convey("Retrieve the credential from JSON file", t, func() {
+		filename := filepath.Join(dir, "secrets.json")
+		ioutil.WriteFile(filename, []byte(`{"AccessKey": "json_key", "SecretKey": "json_secret"}`), 0600)
+
+		credential, err := FileProvider{Filename: filename}.Retrieve()
+		So(err, ShouldBeNil)
+		So(credential, ShouldResemble, Credential{AccessKey: "json_key", SecretKey: "json_secret"})
+	})
+
+	Convey("Retrieve the credential from YAML file", t, func() {
+		filename := filepath.Join(dir, "secrets.yml")
+		ioutil.WriteFile(filename, []byte("AccessKey: yaml_key\nSecretKey: yaml_secret\n"), 0600)
+
+		credential, err := FileProvider{Filename: filename}.Retrieve()
+		So(err, ShouldBeNil)
+		So(credential, ShouldResemble, Credential{AccessKey: "yaml_key", SecretKey: "yaml_secret"})
+	})
+
+	Convey("Error for invalid file", t, func() {
+		filename := filepath.Join(dir, "secrets.json")
+		ioutil.WriteFile(filename, []byte(`{`), 0600)
+
+		_, err := FileProvider{Filename: filename}.Retrieve()
+		So(err, ShouldNotBeNil)
+		So(errors.Is(err, ErrCredentialNotFound), ShouldBeFalse)
+	})
+}
+
+func TestProcessProvider(t *testing.T) {
+	Convey("Retrieve the credential from the command output", t, func() {
+		provider := ProcessProvider{Command: []string{"sh", "-c",
+			`echo '{"Version": 1, "AccessKeyId": "process_key", "SecretAccessKey": "process_secret", "Expiration": "2017-01-01T00:00:00Z"}'`,
+		}}
+
+		credential, err := provider.Retrieve()
+		So(err, ShouldBeNil)
+		So(credential.AccessKey, ShouldEqual, "process_key")
+		So(credential.SecretKey, ShouldEqual, "process_secret")
+		So(credential.Expires, ShouldEqual, time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))
+	})
+
+	Convey("Error when the command failed", t, func() {
+		_, err := ProcessProvider{Command: []string{"sh", "-c", "echo failed >&2; exit 1"}}.Retrieve()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "failed")
+	})
+
+	Convey("Error when the command timed out", t, func() {
+		provider := ProcessProvider{Command: []string{"sleep", "10"}, Timeout: 50 * time.Millisecond}
+
+		start := time.Now()
+		_, err := provider.Retrieve()
+		So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+		So(time.Since(start), ShouldBeLessThan, 5*time.Second)
+	})
+}
+
+func TestChainProvider(t *testing.T) {
+	Convey("Retrieve the credential from the first provider has one", t, func() {
+		chain := NewChainProvider(
+			StaticProvider{},
+			StaticProvider{Credential{AccessKey: "a_key", SecretKey: "s_key"}},
+			StaticProvider{Credential{AccessKey: "b_key", SecretKey: "s_key"}},
+		)
+
+		credential, err := chain.Retrieve()
+		So(err, ShouldBeNil)
+		So(credential.AccessKey, ShouldEqual, "a_key")
+	})
+
+	Convey("Not found if no provider has one", t, func() {
+		_, err := NewChainProvider(StaticProvider{}).Retrieve()
+		So(err, ShouldEqual, ErrCredentialNotFound)
+	})
+}
+
+// rotatingProvider return the next credential on each retrieve.
+type rotatingProvider struct {
+	keys      []string
+	expires   time.Time
+	retrieved int
+}
+
+func (p *rotatingProvider) Retrieve() (Credential, error) {
+	key := p.keys[p.retrieved]
+	if p.retrieved < len(p.keys)-1 {
+		p.retrieved++
+	}
+	return Credential{AccessKey: key, SecretKey: testSecretKey, Expires: p.expires}, nil
+}
+
+func TestCredentials(t *testing.T) {
+	Convey("Cache the credential until expired", t, func() {
+		provider := &rotatingProvider{keys: []string{"first", "second"}}
+		credentials := NewCredentials(provider)
+
+		credential, _ := credentials.Get()
+		So(credential.AccessKey, ShouldEqual, "first")
+		credential, _ = credentials.Get()
+		So(credential.AccessKey, ShouldEqual, "first")
+
+		credentials.Expire()
+		credential, _ = credentials.Get()
+		So(credential.AccessKey, ShouldEqual, "second")
+	})
+
+	Convey("Retrieve again after the expiration", t, func() {
+		provider := &rotatingProvider{keys: []string{"first", "second"}, expires: time.Now().Add(-time.Minute)}
+		credentials := NewCredentials(provider)
+
+		credential, _ := credentials.Get()
+		So(credential.AccessKey, ShouldEqual, "first")
+		credential, _ = credentials.Get()
+		So(credential.AccessKey, ShouldEqual, "second")
+	})
+}
+
+func TestClient_credentials(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.TimestampTolerance = 0
+	server.SetCredential("second", testSecretKey)
+
+	Convey("Retrieve the credential lazily", t, func() {
+		config := testConfig()
+		config.Credentials = NewChainProvider()
+
+		client, err := NewClient(config, "2013-09-01", "Orders")
+		So(err, ShouldBeNil)
+		client.Host = server.Host()
+		client.Transport = mock.NoVerifyTransport()
+
+		_, err = client.SendRequest(Parameters{"Action": "GetOrder"})
+		So(err, ShouldEqual, ErrCredentialNotFound)
+	})
+
+	Convey("Refresh the credential on InvalidAccessKeyId", t, func() {
+		provider := &rotatingProvider{keys: []string{"first", "second"}}
+		config := testConfig()
+		config.Credentials = provider
+
+		client, err := NewClient(config, "2013-09-01", "Orders")
+		So(err, ShouldBeNil)
+		client.Host = server.Host()
+		client.Transport = mock.NoVerifyTransport()
+
+		resp, err := client.SendRequest(Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.Error, ShouldBeNil)
+		So(resp.Attempts, ShouldHaveLength, 2)
+		So(errors.Is(resp.Attempts[0].Err, ErrInvalidAccessKeyId), ShouldBeTrue)
+	})
+}
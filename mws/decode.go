@@ -0,0 +1,91 @@
+package mws
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"time"
+)
+
+// Timestamp is a date time value in ISO 8601 format from the response.
+// The value is kept as it is, some responses have the value url encoded.
+type Timestamp string
+
+// Time parse the timestamp to time.
+// If the timestamp is not valid ISO 8601 format, an error will be returned.
+func (ts Timestamp) Time() (time.Time, error) {
+	value, err := url.QueryUnescape(string(ts))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// UnmarshalElements decode every element with the tag in the xml data.
+// The newItem should return a pointer for the element to decode into, it is
+// 	called once for each element found.
+// Elements nested in a matched element will not be matched again.
+// Ex:
+// 	orders := []Order{}
+// 	err := UnmarshalElements(body, "Order", func() interface{} {
+// 		orders = append(orders, Order{})
+// 		return &orders[len(orders)-1]
+// 	})
+func UnmarshalElements(data []byte, tag string, newItem func() interface{}) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != tag {
+			continue
+		}
+		if err := decoder.DecodeElement(newItem(), &start); err != nil {
+			return err
+		}
+	}
+}
+
+// DecodeResult read the response body, and decode the element with the tag
+// 	into v with the xml tags of v.
+// If the response has error, the response error will be returned.
+func (resp *Response) DecodeResult(tag string, v interface{}) error {
+	found := false
+	err := resp.DecodeResults(tag, func() interface{} {
+		found = true
+		return v
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("No %v found in response", tag)
+	}
+	return nil
+}
+
+// DecodeResults read the response body, and decode all the elements with
+// 	the tag, the newItem should return the pointer for each element.
+// Unlike DecodeResult, no error returned if no element found.
+// If the response has error, the response error will be returned.
+func (resp *Response) DecodeResults(tag string, newItem func() interface{}) error {
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return UnmarshalElements(body, tag, newItem)
+}
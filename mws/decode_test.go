@@ -0,0 +1,111 @@
+package mws
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestUnmarshalElements(t *testing.T) {
+	type message struct {
+		Locale string `xml:"Locale"`
+		Text   string `xml:"Text"`
+	}
+
+	Convey("When data has the elements", t, func() {
+		messages := []message{}
+		err := UnmarshalElements(noErrorTestExample(), "Message", func() interface{} {
+			messages = append(messages, message{})
+			return &messages[len(messages)-1]
+		})
+
+		Convey("No error returned", func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey("All elements decoded", func() {
+			So(len(messages), ShouldEqual, 4)
+			So(messages[0], ShouldResemble, message{Locale: "en_US", Text: "Error message 1"})
+			So(messages[3].Text, ShouldEqual, "Error message 4")
+		})
+	})
+
+	Convey("When data is not valid xml", t, func() {
+		err := UnmarshalElements([]byte("<a><b></a>"), "b", func() interface{} {
+			return &message{}
+		})
+
+		Convey("Error returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestTimestamp_Time(t *testing.T) {
+	Convey("When timestamp is valid", t, func() {
+		ts, err := Timestamp("2013-09-05T00:06:07.000Z").Time()
+
+		So(err, ShouldBeNil)
+		So(ts, ShouldResemble, time.Date(2013, 9, 5, 0, 6, 7, 0, time.UTC))
+	})
+
+	Convey("When timestamp is url encoded", t, func() {
+		ts, err := Timestamp("2013-09-05T00%3A06%3A07.000Z").Time()
+
+		So(err, ShouldBeNil)
+		So(ts, ShouldResemble, time.Date(2013, 9, 5, 0, 6, 7, 0, time.UTC))
+	})
+
+	Convey("When timestamp is not valid", t, func() {
+		_, err := Timestamp("2013-09-071T02:00:00.000-06:00").Time()
+
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestResponse_DecodeResult(t *testing.T) {
+	type result struct {
+		Status string `xml:"Status"`
+	}
+
+	Convey("When response has the result", t, func() {
+		resp := NewResponse(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBuffer(noErrorTestExample())),
+		})
+		r := result{}
+
+		err := resp.DecodeResult("GetServiceStatusResult", &r)
+
+		So(err, ShouldBeNil)
+		So(r.Status, ShouldEqual, "GREEN_I")
+	})
+
+	Convey("When response has no result", t, func() {
+		resp := NewResponse(&http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("<foo>bar</foo>")),
+		})
+
+		err := resp.DecodeResult("GetServiceStatusResult", &result{})
+
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "No GetServiceStatusResult found in response")
+	})
+
+	Convey("When response has error", t, func() {
+		resp := NewResponse(&http.Response{
+			StatusCode: 400,
+			Status:     "400 Bad Request",
+			Body:       ioutil.NopCloser(bytes.NewBuffer(errorTestExample())),
+		})
+
+		err := resp.DecodeResult("GetServiceStatusResult", &result{})
+
+		So(err, ShouldEqual, resp.Error)
+	})
+}
@@ -0,0 +1,87 @@
+package mws
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// CharsetEncoding get the encoding by the charset name, ex: ISO-8859-1.
+// The UTF-16 decoding detect the byte order by the BOM, default to little
+// 	endian, and the encoding write the BOM.
+func CharsetEncoding(charset string) (encoding.Encoding, error) {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "utf-8", "utf8":
+		return unicode.UTF8, nil
+	case "iso-8859-1", "iso8859-1", "iso_8859-1", "latin1", "latin-1":
+		return charmap.ISO8859_1, nil
+	case "windows-1252", "cp1252":
+		return charmap.Windows1252, nil
+	case "shift_jis", "shift-jis", "sjis", "windows-31j", "cp932", "ms932":
+		return japanese.ShiftJIS, nil
+	case "euc-jp":
+		return japanese.EUCJP, nil
+	case "utf-16", "utf16":
+		return unicode.UTF16(unicode.LittleEndian, unicode.UseBOM), nil
+	case "utf-16le":
+		return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), nil
+	case "utf-16be":
+		return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), nil
+	}
+	return nil, fmt.Errorf("Unsupported charset %v", charset)
+}
+
+// ContentCharset get the charset of the content by the content type.
+// If the content type has charset, it override the default one.
+// XML content is UTF-8 by default, other content use the encoding of
+// 	the region.
+func ContentCharset(region, contentType string) string {
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	if charset := params["charset"]; charset != "" {
+		return charset
+	}
+	if strings.Contains(mediaType, "xml") {
+		return "UTF-8"
+	}
+	return Encoding(region)
+}
+
+// NewDecodeReader create a reader decode the content to UTF-8.
+// The charset is decided by the region and the content type, see ContentCharset.
+func NewDecodeReader(r io.Reader, region, contentType string) (io.Reader, error) {
+	enc, err := CharsetEncoding(ContentCharset(region, contentType))
+	if err != nil {
+		return nil, err
+	}
+	return transform.NewReader(r, enc.NewDecoder()), nil
+}
+
+// EncodeContent encode the UTF-8 content to the charset decided by the region
+// 	and the content type, see ContentCharset.
+// The content type with the charset is returned along with the encoded content.
+func EncodeContent(content []byte, region, contentType string) ([]byte, string, error) {
+	charset := ContentCharset(region, contentType)
+	enc, err := CharsetEncoding(charset)
+	if err != nil {
+		return nil, "", err
+	}
+
+	encoded, _, err := transform.Bytes(enc.NewEncoder(), content)
+	if err != nil {
+		return nil, "", err
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, "", err
+	}
+	params["charset"] = charset
+	return encoded, mime.FormatMediaType(mediaType, params), nil
+}
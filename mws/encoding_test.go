@@ -0,0 +1,113 @@
+package mws
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func decode(content []byte, region, contentType string) (string, error) {
+	reader, err := NewDecodeReader(bytes.NewReader(content), region, contentType)
+	if err != nil {
+		return "", err
+	}
+	decoded, err := ioutil.ReadAll(reader)
+	return string(decoded), err
+}
+
+func TestContentCharset(t *testing.T) {
+	Convey("Use the charset in content type", t, func() {
+		So(ContentCharset("US", "text/plain;charset=Cp1252"), ShouldEqual, "Cp1252")
+	})
+
+	Convey("Use UTF-8 for xml content", t, func() {
+		So(ContentCharset("JP", "text/xml"), ShouldEqual, "UTF-8")
+	})
+
+	Convey("Use the encoding of the region", t, func() {
+		So(ContentCharset("JP", ""), ShouldEqual, "Shift_JIS")
+		So(ContentCharset("CN", "application/octet-stream"), ShouldEqual, "UTF-16")
+		So(ContentCharset("DE", "text/tab-separated-values"), ShouldEqual, "ISO-8859-1")
+	})
+}
+
+func TestNewDecodeReader(t *testing.T) {
+	Convey("Decode Shift_JIS for JP", t, func() {
+		decoded, err := decode([]byte{0x83, 0x65, 0x83, 0x58, 0x83, 0x67}, "JP", "")
+
+		So(err, ShouldBeNil)
+		So(decoded, ShouldEqual, "テスト")
+	})
+
+	Convey("Decode UTF-16 by the BOM for CN", t, func() {
+		decoded, err := decode([]byte{0xfe, 0xff, 0x4e, 0x2d, 0x65, 0x87}, "CN", "")
+		So(err, ShouldBeNil)
+		So(decoded, ShouldEqual, "中文")
+
+		decoded, err = decode([]byte{0xff, 0xfe, 0x2d, 0x4e, 0x87, 0x65}, "CN", "")
+		So(err, ShouldBeNil)
+		So(decoded, ShouldEqual, "中文")
+	})
+
+	Convey("Decode Latin-1 for other regions", t, func() {
+		decoded, err := decode([]byte("Caf\xe9"), "FR", "")
+
+		So(err, ShouldBeNil)
+		So(decoded, ShouldEqual, "Café")
+	})
+
+	Convey("Override by the charset in content type", t, func() {
+		decoded, err := decode([]byte("Café"), "JP", "text/plain; charset=UTF-8")
+
+		So(err, ShouldBeNil)
+		So(decoded, ShouldEqual, "Café")
+	})
+
+	Convey("Return error when charset not supported", t, func() {
+		_, err := decode([]byte("abc"), "US", "text/plain; charset=koi8-r")
+
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestEncodeContent(t *testing.T) {
+	Convey("Encode by the region and set charset", t, func() {
+		encoded, contentType, err := EncodeContent([]byte("テスト"), "JP", "text/tab-separated-values")
+
+		So(err, ShouldBeNil)
+		So(encoded, ShouldResemble, []byte{0x83, 0x65, 0x83, 0x58, 0x83, 0x67})
+		So(contentType, ShouldEqual, "text/tab-separated-values; charset=Shift_JIS")
+	})
+
+	Convey("Encode UTF-16 with BOM", t, func() {
+		encoded, _, err := EncodeContent([]byte("中文"), "CN", "text/tab-separated-values")
+
+		So(err, ShouldBeNil)
+		So(encoded, ShouldResemble, []byte{0xff, 0xfe, 0x2d, 0x4e, 0x87, 0x65})
+	})
+
+	Convey("Return error when content can't be encoded", t, func() {
+		_, _, err := EncodeContent([]byte("中文"), "US", "text/tab-separated-values")
+
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestResponse_DecodedBody(t *testing.T) {
+	Convey("Decode the body by the region", t, func() {
+		resp := NewResponse(&http.Response{
+			StatusCode: 200,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("Caf\xe9")),
+		})
+
+		body, err := resp.DecodedBody("UK")
+		So(err, ShouldBeNil)
+		decoded, _ := ioutil.ReadAll(body)
+		So(string(decoded), ShouldEqual, "Café")
+	})
+}
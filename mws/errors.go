@@ -0,0 +1,97 @@
+package mws
+
+import (
+	"strings"
+)
+
+// ErrorCode is the error code returned by MWS.
+// The predefined codes can be used with errors.Is to check the error returned.
+// Ex:
+// 	if errors.Is(response.Error, mws.ErrThrottled) {
+// 		// Slow down.
+// 	}
+type ErrorCode string
+
+func (code ErrorCode) Error() string {
+	return string(code)
+}
+
+// Common MWS error codes.
+// https://docs.developer.amazonservices.com/en_US/dev_guide/DG_Errors.html#ErrorMessages_Service_errors
+var (
+	ErrInputStreamDisconnected = ErrorCode("InputStreamDisconnected")
+	ErrInvalidParameterValue   = ErrorCode("InvalidParameterValue")
+	ErrAccessDenied            = ErrorCode("AccessDenied")
+	ErrInvalidAccessKeyId      = ErrorCode("InvalidAccessKeyId")
+	ErrSignatureDoesNotMatch   = ErrorCode("SignatureDoesNotMatch")
+	ErrInvalidAddress          = ErrorCode("InvalidAddress")
+	ErrInternalError           = ErrorCode("InternalError")
+	ErrQuotaExceeded           = ErrorCode("QuotaExceeded")
+	ErrThrottled               = ErrorCode("RequestThrottled")
+)
+
+// APIError is the error for the request not success.
+// It contains the http status and the MWS errors parsed from the response body.
+type APIError struct {
+	// Http status code of the response.
+	StatusCode int
+	// Http status of the response, ex: "503 Service Unavailable".
+	Status string
+	// Id of the request.
+	RequestID string
+	// MWS errors in the response body, can be empty if the body has no errors.
+	Errors []Error
+}
+
+func (e *APIError) Error() string {
+	msg := "Request not success. Reason: " + e.Status
+	if len(e.Errors) == 0 {
+		return msg
+	}
+
+	msgs := []string{}
+	for _, mwsErr := range e.Errors {
+		msgs = append(msgs, mwsErr.Message)
+	}
+	return msg + ": " + strings.Join(msgs, "\n")
+}
+
+// Is check whether or not the target is an ErrorCode in the MWS errors.
+func (e *APIError) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	if !ok {
+		return false
+	}
+	for _, mwsErr := range e.Errors {
+		if mwsErr.Code == string(code) {
+			return true
+		}
+	}
+	return false
+}
+
+// Codes return the codes of the MWS errors.
+func (e *APIError) Codes() []string {
+	codes := []string{}
+	for _, mwsErr := range e.Errors {
+		codes = append(codes, mwsErr.Code)
+	}
+	return codes
+}
+
+// Retryable check whether or not the request worth to be retried.
+// The request is retryable if any MWS error code is in DefaultRetryableCodes,
+// 	or the status is 500 or 503 without MWS errors.
+func (e *APIError) Retryable() bool {
+	if len(e.Errors) == 0 {
+		return e.StatusCode == 500 || e.StatusCode == 503
+	}
+	for _, code := range e.Codes() {
+		for _, retryableCode := range DefaultRetryableCodes {
+			if code == retryableCode {
+				return true
+			}
+		}
+	}
+	return false
+}
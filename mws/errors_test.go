@@ -0,0 +1,114 @@
+package mws
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAPIError_Error(t *testing.T) {
+	Convey("Error without MWS errors", t, func() {
+		err := &APIError{Status: "503 Service Unavailable"}
+		So(err.Error(), ShouldEqual, "Request not success. Reason: 503 Service Unavailable")
+	})
+
+	Convey("Error with MWS errors", t, func() {
+		err := &APIError{
+			Status: "400 Bad Request",
+			Errors: []Error{{Message: "foo"}, {Message: "bar"}},
+		}
+		So(err.Error(), ShouldEqual, "Request not success. Reason: 400 Bad Request: foo\nbar")
+	})
+}
+
+func TestAPIError_Is(t *testing.T) {
+	err := &APIError{
+		StatusCode: 503,
+		Errors:     []Error{{Code: "RequestThrottled"}},
+	}
+
+	Convey("Match the error code in MWS errors", t, func() {
+		So(errors.Is(err, ErrThrottled), ShouldBeTrue)
+	})
+
+	Convey("Not match other error codes", t, func() {
+		So(errors.Is(err, ErrQuotaExceeded), ShouldBeFalse)
+		So(errors.Is(err, ErrAccessDenied), ShouldBeFalse)
+	})
+}
+
+func TestAPIError_Retryable(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		input  *APIError
+		output bool
+	}{
+		{
+			desc:   "Throttled error is retryable",
+			input:  &APIError{StatusCode: 503, Errors: []Error{{Code: "RequestThrottled"}}},
+			output: true,
+		},
+		{
+			desc:   "Internal error is retryable",
+			input:  &APIError{StatusCode: 500, Errors: []Error{{Code: "InternalError"}}},
+			output: true,
+		},
+		{
+			desc:   "Server error without MWS errors is retryable",
+			input:  &APIError{StatusCode: 503},
+			output: true,
+		},
+		{
+			desc:   "Invalid parameter error is not retryable",
+			input:  &APIError{StatusCode: 400, Errors: []Error{{Code: "InvalidParameterValue"}}},
+			output: false,
+		},
+		{
+			desc:   "Not found without MWS errors is not retryable",
+			input:  &APIError{StatusCode: 404},
+			output: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		Convey(testCase.desc, t, func() {
+			So(testCase.input.Retryable(), ShouldEqual, testCase.output)
+		})
+	}
+}
+
+func TestResponse_Error(t *testing.T) {
+	Convey("When response has MWS errors", t, func() {
+		resp := NewResponse(&http.Response{
+			StatusCode: 400,
+			Status:     "400 Bad Request",
+			Body:       ioutil.NopCloser(bytes.NewBuffer(errorTestExample())),
+		})
+
+		var apiErr *APIError
+		ok := errors.As(resp.Error, &apiErr)
+
+		Convey("Error is an APIError", func() {
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("APIError has the response detail", func() {
+			So(apiErr.StatusCode, ShouldEqual, 400)
+			So(apiErr.RequestID, ShouldEqual, "85c254bd-8214-49a3-95d2-9fadsdad566110")
+			So(apiErr.Errors, ShouldResemble, []Error{{
+				Type:    "Sender",
+				Code:    "InvalidParameterValue",
+				Message: "Value for parameter MarketplaceId is not valid: ATVPDKIKXXX",
+				Detail:  "No comment",
+			}})
+		})
+
+		Convey("Error can be checked by error code", func() {
+			So(errors.Is(resp.Error, ErrInvalidParameterValue), ShouldBeTrue)
+		})
+	})
+}
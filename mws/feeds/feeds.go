@@ -0,0 +1,199 @@
+package feeds
+
+// Reference http://docs.developer.amazonservices.com/en_US/feeds/Feeds_Overview.html
+
+import (
+	"context"
+
+	"github.com/svvu/gomws/mws"
+)
+
+// Feeds is the client for the api
+type Feeds struct {
+	*mws.Client
+}
+
+// NewClient generate a new feeds client
+func NewClient(config mws.Config) (*Feeds, error) {
+	feeds := new(Feeds)
+	base, err := mws.NewClient(config, feeds.Version(), feeds.Name())
+	if err != nil {
+		return nil, err
+	}
+	feeds.Client = base
+	return feeds, nil
+}
+
+// NewPoolClient return the feeds client for the seller in the marketplace from the pool.
+func NewPoolClient(pool *mws.Pool, sellerID, marketplace string) (*Feeds, error) {
+	feeds := new(Feeds)
+	base, err := pool.Client(sellerID, marketplace, feeds.Version(), feeds.Name())
+	if err != nil {
+		return nil, err
+	}
+	feeds.Client = base
+	return feeds, nil
+}
+
+// Version return the current version of api
+func (f Feeds) Version() string {
+	return "2009-01-01"
+}
+
+// Name return the name of the api
+func (f Feeds) Name() string {
+	return "Feeds"
+}
+
+// SubmitFeed Uploads a feed for processing by Amazon MWS.
+// feedType - string. A FeedType value indicating how the data should be processed.
+// 	Values: http://docs.developer.amazonservices.com/en_US/feeds/Feeds_FeedType.html
+// feed - []byte. The UTF-8 feed document, will be send as the request body.
+// 	The feed is encoded to the charset in the content type, or the encoding
+// 	of the marketplace if not set, ex: Shift_JIS for JP. XML feeds keep UTF-8.
+// Optional Parameters:
+// 	MarketplaceIdList - []string. A list of one or more marketplace IDs that you want the feed to be applied to.
+// 	PurgeAndReplace - bool. A Boolean value that enables the purge and replace functionality.
+// 	ContentType - string. The content type of the feed. Default: text/xml for XML feeds.
+// 		Use text/tab-separated-values for flat file feeds.
+// http://docs.developer.amazonservices.com/en_US/feeds/Feeds_SubmitFeed.html
+func (f Feeds) SubmitFeed(feedType string, feed []byte, optional ...mws.Parameters) (*mws.Response, error) {
+	return f.SubmitFeedContext(context.Background(), feedType, feed, optional...)
+}
+
+// SubmitFeedContext is the context version of SubmitFeed.
+func (f Feeds) SubmitFeedContext(ctx context.Context, feedType string, feed []byte, optional ...mws.Parameters) (*mws.Response, error) {
+	op := mws.OptionalParams([]string{
+		"MarketplaceIdList", "PurgeAndReplace", "ContentType",
+	}, optional)
+
+	contentType := "text/xml"
+	if ct, ok := op["ContentType"].(string); ok {
+		contentType = ct
+	}
+	delete(op, "ContentType")
+
+	content, contentType, err := mws.EncodeContent(feed, f.Region, contentType)
+	if err != nil {
+		return nil, err
+	}
+	body := mws.Body{ContentType: contentType, Content: content}
+
+	params := mws.Parameters{
+		"Action":          "SubmitFeed",
+		"FeedType":        feedType,
+		"ContentMD5Value": body.MD5(),
+	}.Merge(op)
+	structuredParams := params.StructureKeys("MarketplaceIdList", "Id")
+
+	return f.SendBodyRequestContext(ctx, structuredParams, body)
+}
+
+// GetFeedSubmissionList Returns a list of all feed submissions submitted in the previous 90 days.
+// Optional Parameters:
+// 	FeedSubmissionIdList - []string. A structured list of no more than 100 FeedSubmmissionId values. If you pass in FeedSubmmissionId values, other query conditions are ignored.
+// 	MaxCount - int. A non-negative integer that indicates the maximum number of feed submissions to return in the list. Max: 100.
+// 	FeedTypeList - []string. A structured list of one or more FeedType values by which to filter the list of feed submissions.
+// 	FeedProcessingStatusList - []string. A structured list of one or more feed processing statuses by which to filter the list of feed submissions.
+// 		Values: _AWAITING_ASYNCHRONOUS_REPLY_, _CANCELLED_, _DONE_, _IN_PROGRESS_, _IN_SAFETY_NET_, _SUBMITTED_, _UNCONFIRMED_.
+// 	SubmittedFromDate - string. The earliest submission date that you are looking for, in ISO8601 date format.
+// 	SubmittedToDate - string. The latest submission date that you are looking for, in ISO8601 date format.
+// http://docs.developer.amazonservices.com/en_US/feeds/Feeds_GetFeedSubmissionList.html
+func (f Feeds) GetFeedSubmissionList(optional ...mws.Parameters) (*mws.Response, error) {
+	return f.GetFeedSubmissionListContext(context.Background(), optional...)
+}
+
+// GetFeedSubmissionListContext is the context version of GetFeedSubmissionList.
+func (f Feeds) GetFeedSubmissionListContext(ctx context.Context, optional ...mws.Parameters) (*mws.Response, error) {
+	op := mws.OptionalParams([]string{
+		"FeedSubmissionIdList", "MaxCount", "FeedTypeList",
+		"FeedProcessingStatusList", "SubmittedFromDate", "SubmittedToDate",
+	}, optional)
+	params := mws.Parameters{"Action": "GetFeedSubmissionList"}.Merge(op)
+	structuredParams := params.StructureKeys("FeedSubmissionIdList", "Id").
+		StructureKeys("FeedTypeList", "Type").
+		StructureKeys("FeedProcessingStatusList", "Status")
+
+	return f.SendRequestContext(ctx, structuredParams)
+}
+
+// GetFeedSubmissionListByNextToken Returns a list of feed submissions using the NextToken parameter.
+// http://docs.developer.amazonservices.com/en_US/feeds/Feeds_GetFeedSubmissionListByNextToken.html
+func (f Feeds) GetFeedSubmissionListByNextToken(nextToken string) (*mws.Response, error) {
+	return f.GetFeedSubmissionListByNextTokenContext(context.Background(), nextToken)
+}
+
+// GetFeedSubmissionListByNextTokenContext is the context version of GetFeedSubmissionListByNextToken.
+func (f Feeds) GetFeedSubmissionListByNextTokenContext(ctx context.Context, nextToken string) (*mws.Response, error) {
+	params := mws.Parameters{
+		"Action":    "GetFeedSubmissionListByNextToken",
+		"NextToken": nextToken,
+	}
+
+	return f.SendRequestContext(ctx, params)
+}
+
+// GetFeedSubmissionCount Returns a count of the feeds submitted in the previous 90 days.
+// Optional Parameters:
+// 	FeedTypeList - []string. A structured list of one or more FeedType values by which to filter the list of feed submissions.
+// 	FeedProcessingStatusList - []string. A structured list of one or more feed processing statuses by which to filter the list of feed submissions.
+// 	SubmittedFromDate - string. The earliest submission date that you are looking for, in ISO8601 date format.
+// 	SubmittedToDate - string. The latest submission date that you are looking for, in ISO8601 date format.
+// http://docs.developer.amazonservices.com/en_US/feeds/Feeds_GetFeedSubmissionCount.html
+func (f Feeds) GetFeedSubmissionCount(optional ...mws.Parameters) (*mws.Response, error) {
+	return f.GetFeedSubmissionCountContext(context.Background(), optional...)
+}
+
+// GetFeedSubmissionCountContext is the context version of GetFeedSubmissionCount.
+func (f Feeds) GetFeedSubmissionCountContext(ctx context.Context, optional ...mws.Parameters) (*mws.Response, error) {
+	op := mws.OptionalParams([]string{
+		"FeedTypeList", "FeedProcessingStatusList",
+		"SubmittedFromDate", "SubmittedToDate",
+	}, optional)
+	params := mws.Parameters{"Action": "GetFeedSubmissionCount"}.Merge(op)
+	structuredParams := params.StructureKeys("FeedTypeList", "Type").
+		StructureKeys("FeedProcessingStatusList", "Status")
+
+	return f.SendRequestContext(ctx, structuredParams)
+}
+
+// CancelFeedSubmissions Cancels one or more feed submissions and returns a count of the feed submissions that were canceled.
+// Optional Parameters:
+// 	FeedSubmissionIdList - []string. A structured list of FeedSubmmissionId values. If you pass in FeedSubmmissionId values, other query conditions are ignored.
+// 	FeedTypeList - []string. A structured list of one or more FeedType values by which to filter the list of feed submissions.
+// 	SubmittedFromDate - string. The earliest submission date that you are looking for, in ISO8601 date format.
+// 	SubmittedToDate - string. The latest submission date that you are looking for, in ISO8601 date format.
+// http://docs.developer.amazonservices.com/en_US/feeds/Feeds_CancelFeedSubmissions.html
+func (f Feeds) CancelFeedSubmissions(optional ...mws.Parameters) (*mws.Response, error) {
+	return f.CancelFeedSubmissionsContext(context.Background(), optional...)
+}
+
+// CancelFeedSubmissionsContext is the context version of CancelFeedSubmissions.
+func (f Feeds) CancelFeedSubmissionsContext(ctx context.Context, optional ...mws.Parameters) (*mws.Response, error) {
+	op := mws.OptionalParams([]string{
+		"FeedSubmissionIdList", "FeedTypeList",
+		"SubmittedFromDate", "SubmittedToDate",
+	}, optional)
+	params := mws.Parameters{"Action": "CancelFeedSubmissions"}.Merge(op)
+	structuredParams := params.StructureKeys("FeedSubmissionIdList", "Id").
+		StructureKeys("FeedTypeList", "Type")
+
+	return f.SendRequestContext(ctx, structuredParams)
+}
+
+// GetFeedSubmissionResult Returns the feed processing report and the Content-MD5 header.
+// feedSubmissionId - string. The identifier of the feed submission you are requesting a feed processing report for.
+// http://docs.developer.amazonservices.com/en_US/feeds/Feeds_GetFeedSubmissionResult.html
+func (f Feeds) GetFeedSubmissionResult(feedSubmissionID string) (*mws.Response, error) {
+	return f.GetFeedSubmissionResultContext(context.Background(), feedSubmissionID)
+}
+
+// GetFeedSubmissionResultContext is the context version of GetFeedSubmissionResult.
+func (f Feeds) GetFeedSubmissionResultContext(ctx context.Context, feedSubmissionID string) (*mws.Response, error) {
+	params := mws.Parameters{
+		"Action":           "GetFeedSubmissionResult",
+		"FeedSubmissionId": feedSubmissionID,
+	}
+
+	return f.SendRequestContext(ctx, params)
+}
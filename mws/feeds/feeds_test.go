@@ -0,0 +1,150 @@
+package feeds
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+	"github.com/svvu/gomws/mws/mock"
+)
+
+func testClient(server *mock.Server) *Feeds {
+	client, _ := NewClient(mws.Config{
+		SellerId:  "SellerID",
+		AuthToken: "AuthToken",
+		Region:    "US",
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+	})
+	client.Host = server.Host()
+	client.Transport = mock.NoVerifyTransport()
+	return client
+}
+
+func exampleResponse(name string) *http.Response {
+	body, _ := ioutil.ReadFile("./exampleResponses/" + name + ".xml")
+	return mock.NewResponse(200, string(body))
+}
+
+func TestFeeds_SubmitFeed(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	client := testClient(server)
+
+	feed := []byte("<AmazonEnvelope></AmazonEnvelope>")
+
+	Convey("Send the feed as request body", t, func() {
+		var request *http.Request
+		var requestBody []byte
+		server.SetResponseHandler(func(r *http.Request) *http.Response {
+			request = r
+			requestBody, _ = ioutil.ReadAll(r.Body)
+			return exampleResponse("SubmitFeed")
+		})
+
+		resp, err := client.SubmitFeed("_POST_PRODUCT_DATA_", feed, mws.Parameters{
+			"MarketplaceIdList": []string{"ATVPDKIKX0DER", "A2EUQ1WTGCTBG2"},
+			"PurgeAndReplace":   false,
+		})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.Error, ShouldBeNil)
+		So(string(requestBody), ShouldEqual, string(feed))
+		So(request.Header.Get("Content-Type"), ShouldEqual, "text/xml; charset=UTF-8")
+		So(request.Header.Get("Content-MD5"), ShouldEqual, mws.Body{Content: feed}.MD5())
+
+		query := request.URL.Query()
+		So(query.Get("Action"), ShouldEqual, "SubmitFeed")
+		So(query.Get("FeedType"), ShouldEqual, "_POST_PRODUCT_DATA_")
+		So(query.Get("ContentMD5Value"), ShouldEqual, mws.Body{Content: feed}.MD5())
+		So(query.Get("MarketplaceIdList.Id.2"), ShouldEqual, "A2EUQ1WTGCTBG2")
+		So(query.Get("PurgeAndReplace"), ShouldEqual, "false")
+		So(query.Get("Signature"), ShouldNotBeEmpty)
+		So(query.Get("ContentType"), ShouldBeEmpty)
+	})
+
+	Convey("Encode flat file feed by the marketplace", t, func() {
+		var contentType string
+		var requestBody []byte
+		server.SetResponseHandler(func(r *http.Request) *http.Response {
+			contentType = r.Header.Get("Content-Type")
+			requestBody, _ = ioutil.ReadAll(r.Body)
+			return exampleResponse("SubmitFeed")
+		})
+
+		resp, err := client.SubmitFeed("_POST_FLAT_FILE_INVLOADER_DATA_", []byte("sku\tname\nSKU-1\tCafé\n"), mws.Parameters{
+			"ContentType": "text/tab-separated-values",
+		})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(contentType, ShouldEqual, "text/tab-separated-values; charset=ISO-8859-1")
+		So(string(requestBody), ShouldEqual, "sku\tname\nSKU-1\tCaf\xe9\n")
+	})
+
+	Convey("Encode flat file feed by the charset in content type", t, func() {
+		var contentType string
+		server.SetResponseHandler(func(r *http.Request) *http.Response {
+			contentType = r.Header.Get("Content-Type")
+			return exampleResponse("SubmitFeed")
+		})
+
+		resp, err := client.SubmitFeed("_POST_FLAT_FILE_INVLOADER_DATA_", feed, mws.Parameters{
+			"ContentType": "text/tab-separated-values; charset=UTF-8",
+		})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(contentType, ShouldEqual, "text/tab-separated-values; charset=UTF-8")
+	})
+
+	Convey("Return error when charset not supported", t, func() {
+		resp, err := client.SubmitFeed("_POST_FLAT_FILE_INVLOADER_DATA_", feed, mws.Parameters{
+			"ContentType": "text/tab-separated-values; charset=koi8-r",
+		})
+
+		So(resp, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestFeeds_GetFeedSubmissionListPager(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	client := testClient(server)
+
+	Convey("Walk through all the feed submissions", t, func() {
+		server.SetResponseHandler(func(r *http.Request) *http.Response {
+			r.ParseForm()
+			return exampleResponse(r.PostForm.Get("Action"))
+		})
+
+		pager := client.GetFeedSubmissionListPager()
+		infos := []FeedSubmissionInfo{}
+		for pager.Next(context.Background()) {
+			infos = append(infos, *pager.Value().(*FeedSubmissionInfo))
+		}
+
+		So(pager.Err(), ShouldBeNil)
+
+		So(len(infos), ShouldEqual, 2)
+		So(infos[0].FeedSubmissionId, ShouldEqual, "2291326430")
+		So(infos[1].FeedProcessingStatus, ShouldEqual, StatusDone)
+		So(infos[1].CompletedProcessingDate, ShouldEqual, "2009-02-20T02:14:20+00:00")
+	})
+}
+
+func TestNewPoolClient(t *testing.T) {
+	pool := mws.NewPool(mws.StaticProvider{Credential: mws.Credential{AccessKey: "AccessKey", SecretKey: "SecretKey"}})
+	pool.Authorize("SellerID", "AuthToken")
+
+	Convey("Feeds client of the seller from the pool", t, func() {
+		client, err := NewPoolClient(pool, "SellerID", "UK")
+		So(err, ShouldBeNil)
+		So(client.Path(), ShouldEqual, "/Feeds/2009-01-01")
+	})
+}
@@ -0,0 +1,23 @@
+package feeds
+
+// Feed processing status.
+const (
+	StatusAwaitingAsynchronousReply = "_AWAITING_ASYNCHRONOUS_REPLY_"
+	StatusCancelled                 = "_CANCELLED_"
+	StatusDone                      = "_DONE_"
+	StatusInProgress                = "_IN_PROGRESS_"
+	StatusInSafetyNet               = "_IN_SAFETY_NET_"
+	StatusSubmitted                 = "_SUBMITTED_"
+	StatusUnconfirmed               = "_UNCONFIRMED_"
+)
+
+// FeedSubmissionInfo detailed information about a feed submission.
+// http://docs.developer.amazonservices.com/en_US/feeds/Feeds_FeedSubmissionInfo.html
+type FeedSubmissionInfo struct {
+	FeedSubmissionId        string `xml:"FeedSubmissionId"`
+	FeedType                string `xml:"FeedType"`
+	SubmittedDate           string `xml:"SubmittedDate"`
+	FeedProcessingStatus    string `xml:"FeedProcessingStatus"`
+	StartedProcessingDate   string `xml:"StartedProcessingDate"`
+	CompletedProcessingDate string `xml:"CompletedProcessingDate"`
+}
@@ -0,0 +1,22 @@
+package feeds
+
+import (
+	"context"
+
+	"github.com/svvu/gomws/mws"
+)
+
+// GetFeedSubmissionListPager create a pager for GetFeedSubmissionList, the
+// 	items are *FeedSubmissionInfo.
+// The parameters are the same as GetFeedSubmissionList.
+func (f Feeds) GetFeedSubmissionListPager(optional ...mws.Parameters) *mws.Pager {
+	fetch := func(ctx context.Context, nextToken string) (*mws.Response, error) {
+		if nextToken == "" {
+			return f.GetFeedSubmissionListContext(ctx, optional...)
+		}
+		return f.GetFeedSubmissionListByNextTokenContext(ctx, nextToken)
+	}
+
+	newInfo := func() interface{} { return &FeedSubmissionInfo{} }
+	return mws.NewPager(fetch, "FeedSubmissionInfo", newInfo).Pace(f.Client, "GetFeedSubmissionListByNextToken")
+}
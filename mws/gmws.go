@@ -2,6 +2,7 @@ package mws
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"time"
 
@@ -34,8 +35,42 @@ type Config struct {
 	SellerId  string
 	AuthToken string
 	Region    string
-	AccessKey string
-	SecretKey string
+	// Marketplaces the ids of the marketplaces for the requests, ex: ListOrders
+	// 	and RequestReport, up to 50. All must share the endpoint of the
+	// 	region, default to the marketplace of the region.
+	// The region is default to the region of the first marketplace, which is
+	// 	also the marketplace for the operations accept only one, ex: Products.
+	Marketplaces []string
+	AccessKey    string
+	SecretKey    string
+	// Credentials provide the credential lazily on the first request, and
+	// 	again once MWS return InvalidAccessKeyId, ex: a ChainProvider of the
+	// 	SharedFileProvider and ProcessProvider.
+	// AccessKey and SecretKey are ignored if set.
+	Credentials CredentialProvider
+	// Endpoint the full url to send the requests, override the endpoint of
+	// 	the region, ex: http://localhost:8080 for a local fake, or the url
+	// 	with a path prefix for a recording proxy.
+	// The requests are signed by the host and path of the url.
+	Endpoint string
+	// HTTPClient to send the requests, ex: a client shared by many API
+	// 	clients. Default to a new http.Client.
+	HTTPClient *http.Client
+	// Transport of the http client, ex: a mock.Recorder. Can't be set with
+	// 	HTTPClient, set the Transport of the HTTPClient instead.
+	Transport http.RoundTripper
+}
+
+// CredentialProvider return the Credentials provider if set, otherwise a
+// 	chain of the AccessKey and SecretKey in config and the env variables.
+func (config Config) CredentialProvider() CredentialProvider {
+	if config.Credentials != nil {
+		return config.Credentials
+	}
+	return NewChainProvider(
+		StaticProvider{Credential{AccessKey: config.AccessKey, SecretKey: config.SecretKey}},
+		EnvProvider{},
+	)
 }
 
 // Credential return credential either from value set in config or load from env variables.
@@ -54,6 +89,8 @@ func (config Config) Credential() Credential {
 type Credential struct {
 	AccessKey string
 	SecretKey string
+	// Time the credential expired, zero if never.
+	Expires time.Time
 }
 
 // GetCredential get the credential from evn variables.
@@ -77,6 +114,13 @@ type Error struct {
 }
 
 // Inspect print out the value in a user friendly way.
+// The credentials and the RedactedKeys are redacted, ex: the AuthToken of
+// 	the client, and the Signature in the parameters.
 func Inspect(value interface{}) {
-	fmt.Printf("%# v", pretty.Formatter(value))
+	fmt.Print(inspect(value))
+}
+
+// inspect format the value for Inspect.
+func inspect(value interface{}) string {
+	return currentPatterns().redactText(fmt.Sprintf("%# v", pretty.Formatter(value)))
 }
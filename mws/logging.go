@@ -0,0 +1,259 @@
+package mws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redacted replace the values of the secret parameters and elements.
+const Redacted = "[REDACTED]"
+
+// RedactedKeys the parameters and xml elements always redacted in the logs,
+// 	the credentials and the buyer PII.
+// The structured parameters are redacted if any part of the key match,
+// 	ex: ShippingAddress.Name.
+var RedactedKeys = []string{
+	"AWSAccessKeyId", "MWSAuthToken", "Signature", "SecretKey",
+	"BuyerEmail", "BuyerName", "BuyerPhoneNumber", "BuyerCounty", "BuyerTaxInfo",
+	"ShippingAddress", "BillingAddress", "Phone", "RecipientName",
+}
+
+// secretFields the fields of the config, client and credential redacted by
+// 	Inspect besides the RedactedKeys.
+var secretFields = []string{"AccessKey", "SecretKey", "AuthToken"}
+
+// redactPatterns the patterns compiled from the RedactedKeys.
+type redactPatterns struct {
+	keys []string
+	// The xml elements, one per key.
+	elements []*regexp.Regexp
+	// The struct fields and map keys followed by the value in the pretty
+	// 	printed text, ex: AuthToken: "token".
+	fields *regexp.Regexp
+	// The parameters in the query strings, ex: Signature=signature.
+	params *regexp.Regexp
+}
+
+var (
+	patternsMu sync.Mutex
+	patterns   *redactPatterns
+)
+
+// currentPatterns return the patterns of the RedactedKeys, compiled again
+// 	only when the RedactedKeys changed.
+func currentPatterns() *redactPatterns {
+	patternsMu.Lock()
+	defer patternsMu.Unlock()
+	if patterns == nil || !equalKeys(patterns.keys, RedactedKeys) {
+		patterns = compilePatterns(RedactedKeys)
+	}
+	return patterns
+}
+
+// equalKeys check whether or not the keys are the same.
+func equalKeys(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// compilePatterns compile the patterns of the keys.
+func compilePatterns(keys []string) *redactPatterns {
+	p := &redactPatterns{keys: append([]string{}, keys...)}
+	names := []string{}
+	for _, key := range keys {
+		name := regexp.QuoteMeta(key)
+		names = append(names, name)
+		p.elements = append(p.elements, regexp.MustCompile(`(?s)<(`+name+`)(\s[^>]*)?>.*?</`+name+`>`))
+	}
+	for _, key := range secretFields {
+		names = append(names, regexp.QuoteMeta(key))
+	}
+
+	// The structured keys are matched by any part, ex: ShippingAddress.Name.
+	name := `(?i)\b(?:[\w-]+\.)*(?:` + strings.Join(names, "|") + `)(?:\.[\w-]+)*`
+	p.fields = regexp.MustCompile(name + `"?:\s*`)
+	p.params = regexp.MustCompile(`(` + name + `=)[^&"\s]*`)
+	return p
+}
+
+// redactText redact the secret fields, query parameters and xml elements in
+// 	the pretty printed text.
+func (p *redactPatterns) redactText(text string) string {
+	var out strings.Builder
+	last := 0
+	for _, loc := range p.fields.FindAllStringIndex(text, -1) {
+		if loc[0] < last {
+			// Inside the value redacted already.
+			continue
+		}
+		out.WriteString(text[last:loc[1]])
+		out.WriteString(strconv.Quote(Redacted))
+		last = valueEnd(text, loc[1])
+	}
+	out.WriteString(text[last:])
+
+	text = p.params.ReplaceAllString(out.String(), "${1}"+url.QueryEscape(Redacted))
+	return string(p.redactElements([]byte(text)))
+}
+
+// redactElements redact the content of the xml elements.
+func (p *redactPatterns) redactElements(body []byte) []byte {
+	for _, element := range p.elements {
+		body = element.ReplaceAll(body, []byte("<$1$2>"+Redacted+"</$1>"))
+	}
+	return body
+}
+
+// valueEnd return the end of the value start at i in the pretty printed
+// 	text, a quoted string, a composite value in braces, ex:
+// 	&orders.Address{...}, or others end by comma or new line.
+func valueEnd(text string, i int) int {
+	depth := 0
+	quoted := false
+	for ; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+			if !quoted && depth == 0 {
+				return i + 1
+			}
+		case quoted:
+		case c == '{' || c == '(' || c == '[':
+			depth++
+		case c == '}' || c == ')' || c == ']':
+			if depth == 0 {
+				return i
+			}
+			depth--
+			if depth == 0 && c == '}' {
+				return i + 1
+			}
+		case depth == 0 && (c == ',' || c == '\n'):
+			return i
+		}
+	}
+	return i
+}
+
+// Logger log the requests, *slog.Logger can be used. Ex:
+// 	client.Logger = slog.New(slog.NewJSONHandler(os.Stderr, nil))
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...interface{})
+	InfoContext(ctx context.Context, msg string, args ...interface{})
+	ErrorContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// redactedKey check whether or not the parameter should be redacted.
+func redactedKey(key string) bool {
+	for _, part := range strings.Split(key, ".") {
+		for _, redacted := range RedactedKeys {
+			if part == redacted {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RedactValues return a copy of the values with the RedactedKeys redacted.
+func RedactValues(values url.Values) url.Values {
+	redacted := url.Values{}
+	for key, vals := range values {
+		if redactedKey(key) {
+			redacted[key] = []string{Redacted}
+			continue
+		}
+		redacted[key] = append([]string{}, vals...)
+	}
+	return redacted
+}
+
+// RedactBody return a copy of the xml body with the content of the
+// 	RedactedKeys elements redacted, ex:
+// 	<BuyerEmail>[REDACTED]</BuyerEmail>
+func RedactBody(body []byte) []byte {
+	return currentPatterns().redactElements(body)
+}
+
+// redactError redact the query string in the url of the error, the signed
+// 	parameters are in the url of the request with body.
+func redactError(err error) string {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err.Error()
+	}
+
+	u, parseErr := url.Parse(urlErr.URL)
+	if parseErr != nil {
+		return urlErr.Err.Error()
+	}
+	u.RawQuery = RedactValues(u.Query()).Encode()
+	return (&url.Error{Op: urlErr.Op, URL: u.String(), Err: urlErr.Err}).Error()
+}
+
+// LoggingMiddleware log the action, service, marketplace, status, latency,
+// 	request id and MWS error codes of the requests.
+// The parameters, and the body of the error responses are logged in debug
+// 	level, with the RedactedKeys redacted.
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			attrs := []interface{}{
+				"action", req.Action,
+				"service", req.Name,
+				"version", req.Version,
+				"seller", req.SellerId,
+				"marketplace", req.MarketplaceId,
+			}
+			if params, err := req.Params.Normalize(); err == nil {
+				logger.DebugContext(ctx, "MWS request", append(attrs, "params", RedactValues(params.Values).Encode())...)
+			}
+
+			start := time.Now()
+			resp, err := next(ctx, req)
+			attrs = append(attrs, "latency", time.Since(start))
+			if err != nil {
+				logger.ErrorContext(ctx, "MWS request failed", append(attrs, "error", redactError(err))...)
+				return nil, err
+			}
+
+			attrs = append(attrs,
+				"status", resp.StatusCode,
+				"request_id", resp.RequestID(),
+				"attempts", len(resp.Attempts),
+			)
+			var apiErr *APIError
+			if !errors.As(resp.Error, &apiErr) {
+				logger.InfoContext(ctx, "MWS request", attrs...)
+				return resp, nil
+			}
+
+			logger.ErrorContext(ctx, "MWS request not success", append(attrs, "error_codes", apiErr.Codes())...)
+			// The error response body is buffered already, see parseResponseError.
+			if body, err := ioutil.ReadAll(resp.Body); err == nil {
+				resp.Body.Close()
+				resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+				logger.DebugContext(ctx, "MWS error response", append(attrs, "body", string(RedactBody(body)))...)
+			}
+			return resp, nil
+		}
+	}
+}
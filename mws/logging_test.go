@@ -0,0 +1,200 @@
+package mws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws/mock"
+)
+
+// testLogger write the logs in the text format of slog, ex:
+// 	level=INFO msg="MWS request" action=ListOrders
+type testLogger struct {
+	out *bytes.Buffer
+}
+
+func (l testLogger) log(level, msg string, args []interface{}) {
+	fmt.Fprintf(l.out, "level=%v msg=%q", level, msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(l.out, " %v=%v", args[i], args[i+1])
+	}
+	l.out.WriteString("\n")
+}
+
+func (l testLogger) DebugContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("DEBUG", msg, args)
+}
+
+func (l testLogger) InfoContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("INFO", msg, args)
+}
+
+func (l testLogger) ErrorContext(ctx context.Context, msg string, args ...interface{}) {
+	l.log("ERROR", msg, args)
+}
+
+func TestRedactValues(t *testing.T) {
+	Convey("Redact the credentials and PII", t, func() {
+		values := url.Values{
+			"Action":                      {"ListOrders"},
+			"AWSAccessKeyId":              {"AccessKey"},
+			"Signature":                   {"Signature"},
+			"MWSAuthToken":                {"AuthToken"},
+			"BuyerEmail":                  {"buyer@example.com"},
+			"ShippingAddress.AddressLine": {"1 Street"},
+		}
+		redacted := RedactValues(values)
+
+		So(redacted, ShouldResemble, url.Values{
+			"Action":                      {"ListOrders"},
+			"AWSAccessKeyId":              {Redacted},
+			"Signature":                   {Redacted},
+			"MWSAuthToken":                {Redacted},
+			"BuyerEmail":                  {Redacted},
+			"ShippingAddress.AddressLine": {Redacted},
+		})
+		So(values.Get("BuyerEmail"), ShouldEqual, "buyer@example.com")
+	})
+}
+
+func TestRedactBody(t *testing.T) {
+	Convey("Redact the PII elements", t, func() {
+		body := []byte("<Order><BuyerEmail>buyer@example.com</BuyerEmail>" +
+			"<ShippingAddress>\n<Name>Buyer</Name>\n</ShippingAddress>" +
+			"<OrderStatus>Shipped</OrderStatus></Order>")
+
+		So(string(RedactBody(body)), ShouldEqual, "<Order><BuyerEmail>[REDACTED]</BuyerEmail>"+
+			"<ShippingAddress>[REDACTED]</ShippingAddress>"+
+			"<OrderStatus>Shipped</OrderStatus></Order>")
+	})
+
+	Convey("Compile the patterns again when the keys changed", t, func() {
+		body := []byte("<OrderStatus>Shipped</OrderStatus>")
+		So(string(RedactBody(body)), ShouldEqual, "<OrderStatus>Shipped</OrderStatus>")
+		So(currentPatterns(), ShouldPointTo, currentPatterns())
+
+		keys := RedactedKeys
+		RedactedKeys = append([]string{"OrderStatus"}, keys...)
+		defer func() { RedactedKeys = keys }()
+
+		So(string(RedactBody(body)), ShouldEqual, "<OrderStatus>[REDACTED]</OrderStatus>")
+	})
+}
+
+func TestInspect(t *testing.T) {
+	Convey("Redact the credentials of the client", t, func() {
+		client, _ := NewClient(testConfig(), "2013-09-01", "Orders")
+
+		text := strings.Join(strings.Fields(inspect(client)), " ")
+		So(text, ShouldContainSubstring, `SellerId: "SellerID"`)
+		So(text, ShouldContainSubstring, `AuthToken: "[REDACTED]"`)
+		So(text, ShouldNotContainSubstring, `"AuthToken"`)
+		So(text, ShouldNotContainSubstring, `"`+testAccessKey+`"`)
+		So(text, ShouldNotContainSubstring, `"`+testSecretKey+`"`)
+	})
+
+	Convey("Redact the signed parameters", t, func() {
+		text := strings.Join(strings.Fields(inspect(Parameters{
+			"Action":         "ListOrders",
+			"AWSAccessKeyId": "AccessKey",
+			"MWSAuthToken":   "AuthToken",
+			"Signature":      "Signature",
+			"ShippingAddress": map[string]string{
+				"Name": "Buyer",
+			},
+		})), " ")
+
+		So(text, ShouldContainSubstring, `"Action": "ListOrders"`)
+		So(text, ShouldContainSubstring, `"AWSAccessKeyId": "[REDACTED]"`)
+		So(text, ShouldContainSubstring, `"MWSAuthToken": "[REDACTED]"`)
+		So(text, ShouldContainSubstring, `"Signature": "[REDACTED]"`)
+		So(text, ShouldContainSubstring, `"ShippingAddress": "[REDACTED]"`)
+		So(text, ShouldNotContainSubstring, "Buyer")
+	})
+
+	Convey("Redact the query string and xml", t, func() {
+		text := inspect(struct {
+			URL  string
+			Body string
+		}{
+			"https://mws.amazonservices.com/?AWSAccessKeyId=AccessKey&Signature=Signature",
+			"<BuyerEmail>buyer@example.com</BuyerEmail>",
+		})
+
+		So(text, ShouldContainSubstring, "AWSAccessKeyId=%5BREDACTED%5D&Signature=%5BREDACTED%5D")
+		So(text, ShouldContainSubstring, "<BuyerEmail>[REDACTED]</BuyerEmail>")
+	})
+}
+
+func TestRedactError(t *testing.T) {
+	Convey("Redact the signed parameters in the url", t, func() {
+		err := &url.Error{
+			Op:  "Post",
+			URL: "https://mws.amazonservices.com/Feeds/2009-01-01?AWSAccessKeyId=AccessKey&Action=SubmitFeed&Signature=Signature",
+			Err: errors.New("connection refused"),
+		}
+
+		So(redactError(err), ShouldEqual, `Post "https://mws.amazonservices.com/Feeds/2009-01-01?`+
+			`AWSAccessKeyId=%5BREDACTED%5D&Action=SubmitFeed&Signature=%5BREDACTED%5D": connection refused`)
+	})
+}
+
+func TestClient_Logger(t *testing.T) {
+	server := mock.NewHTTPServer()
+	defer server.Close()
+	server.TimestampTolerance = 0
+	server.HandleAction("Orders", "ListOrders", func(req *mock.Request) *http.Response {
+		resp := mock.NewResponse(200, "<ListOrdersResponse/>")
+		resp.Header.Set("x-mws-request-id", "request-id")
+		return resp
+	})
+	server.HandleAction("Orders", "GetOrder", func(req *mock.Request) *http.Response {
+		return mock.NewErrorResponse(400, "InvalidParameterValue", "Invalid order id")
+	})
+
+	var out bytes.Buffer
+	config := testConfig()
+	config.Endpoint = server.URL
+	client, _ := NewClient(config, "2013-09-01", "Orders")
+	client.Logger = testLogger{&out}
+
+	Convey("Log the request", t, func() {
+		out.Reset()
+		resp, err := client.SendRequest(Parameters{"Action": "ListOrders", "BuyerEmail": "buyer@example.com"})
+		So(err, ShouldBeNil)
+		resp.Close()
+
+		log := out.String()
+		So(log, ShouldContainSubstring, "action=ListOrders service=Orders version=2013-09-01 seller=SellerID marketplace=ATVPDKIKX0DER")
+		So(log, ShouldContainSubstring, "status=200 request_id=request-id attempts=1")
+		So(log, ShouldContainSubstring, "latency=")
+		So(log, ShouldContainSubstring, "BuyerEmail=%5BREDACTED%5D")
+		So(log, ShouldNotContainSubstring, "buyer@example.com")
+	})
+
+	Convey("Log the error response", t, func() {
+		out.Reset()
+		resp, err := client.SendRequest(Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		log := out.String()
+		So(log, ShouldContainSubstring, "level=ERROR")
+		So(log, ShouldContainSubstring, "status=400")
+		So(log, ShouldContainSubstring, "error_codes=[InvalidParameterValue]")
+		So(log, ShouldNotContainSubstring, "AccessKey")
+
+		Convey("The body is kept for the caller", func() {
+			body, _ := ioutil.ReadAll(resp.Body)
+			So(string(body), ShouldContainSubstring, "InvalidParameterValue")
+		})
+	})
+}
@@ -1,36 +1,27 @@
 package mws
 
 import (
+	"errors"
 	"fmt"
 )
 
 // EndPoints a list of API endpoints by marketpalceID
-var EndPoints = map[string]string{
-	"A2EUQ1WTGCTBG2": "mws.amazonservices.ca",
-	"ATVPDKIKX0DER":  "mws.amazonservices.com",
-	"A1PA6795UKMFR9": "mws-eu.amazonservices.com",
-	"A1RKKUPIHCS9HS": "mws-eu.amazonservices.com",
-	"A13V1IB3VIYZZH": "mws-eu.amazonservices.com",
-	"A21TJRUUN4KGV":  "mws.amazonservices.in",
-	"APJ6JRA9NG5V4":  "mws-eu.amazonservices.com",
-	"A1F83G8C2ARO7P": "mws-eu.amazonservices.com",
-	"A1VC38T7YXB528": "mws.amazonservices.jp",
-	"AAHKV2X7AFYLW":  "mws.amazonservices.com.cn",
-}
+// The changes are still read by the lookups of the DefaultCatalog, and the
+// 	marketplaces registered are added.
+//
+// Deprecated: use LookupMarketplace and RegisterEndPoint.
+var EndPoints = DefaultCatalog.endPoints()
 
 // MarketPlaceIds a list of marketplace by region
-var MarketPlaceIds = map[string]string{
-	"CA": "A2EUQ1WTGCTBG2",
-	"US": "ATVPDKIKX0DER",
-	"DE": "A1PA6795UKMFR9",
-	"ES": "A1RKKUPIHCS9HS",
-	"FR": "A13V1IB3VIYZZH",
-	"IN": "A21TJRUUN4KGV",
-	"IT": "APJ6JRA9NG5V4",
-	"UK": "A1F83G8C2ARO7P",
-	"JP": "A1VC38T7YXB528",
-	"CN": "AAHKV2X7AFYLW",
-}
+// The changes are still read by the lookups of the DefaultCatalog, and the
+// 	marketplaces registered are added.
+//
+// Deprecated: use LookupMarketplace and RegisterMarketplace.
+var MarketPlaceIds = DefaultCatalog.ids()
+
+// ErrMixedMarketplaces is returned when the marketplaces of a client are not
+// 	served by the same endpoint, ex: US and DE.
+var ErrMixedMarketplaces = errors.New("Marketplaces from different regions")
 
 // MarketPlaceError for marketplace.
 // There are two type of errors: marketplace id error and region error.
@@ -73,8 +64,8 @@ func (mp *MarketPlace) MarketPlaceEndPoint() (string, error) {
 	if mp.EndPoint != "" {
 		return mp.EndPoint, nil
 	}
-	if val, ok := EndPoints[mp.Id]; ok {
-		return val, nil
+	if val, ok := LookupMarketplace(mp.Id); ok && val.ID == mp.Id {
+		return val.EndPoint, nil
 	}
 	return "", MarketPlaceError{"marketplace id", mp.Id}
 }
@@ -84,19 +75,43 @@ func (mp *MarketPlace) MarketPlaceId() (string, error) {
 	if mp.Id != "" {
 		return mp.Id, nil
 	}
-	if val, ok := MarketPlaceIds[mp.Region]; ok {
-		return val, nil
+	if val, ok := LookupMarketplace(mp.Region); ok {
+		return val.ID, nil
 	}
 	return "", MarketPlaceError{"region", mp.Region}
 }
 
+// MarketPlaceRegion get the region of the marketpalce id.
+func MarketPlaceRegion(id string) (string, error) {
+	if val, ok := LookupMarketplace(id); ok && val.ID == id {
+		return val.CountryCode, nil
+	}
+	return "", MarketPlaceError{"marketplace id", id}
+}
+
+// MarketPlacesEndPoint get the MWS end point shared by the marketplace ids.
+// ErrMixedMarketplaces is returned if the marketplaces are in different
+// 	regions, MWS only accept the marketplaces of its endpoint.
+func MarketPlacesEndPoint(ids []string) (string, error) {
+	endPoint := ""
+	for i, id := range ids {
+		mp := MarketPlace{Id: id}
+		val, err := mp.MarketPlaceEndPoint()
+		if err != nil {
+			return "", err
+		}
+		if i > 0 && val != endPoint {
+			return "", fmt.Errorf("%w: %v (%v) and %v (%v)", ErrMixedMarketplaces, ids[0], endPoint, id, val)
+		}
+		endPoint = val
+	}
+	return endPoint, nil
+}
+
 // Encoding get the ecoding for file upload and parsing
-// TODO add encoding for JP.
 func Encoding(region string) string {
-	switch region {
-	case "CN":
-		return "UTF-16"
-	default:
-		return "ISO-8859-1"
+	if val, ok := LookupMarketplace(region); ok && val.Encoding != "" {
+		return val.Encoding
 	}
+	return "ISO-8859-1"
 }
@@ -78,6 +78,41 @@ func TestNewMarketPlace(t *testing.T) {
 				id:       "AAHKV2X7AFYLW",
 				endpoint: "mws.amazonservices.com.cn",
 			},
+			{
+				region:   "BR",
+				id:       "A2Q3Y263D00KWC",
+				endpoint: "mws.amazonservices.com",
+			},
+			{
+				region:   "MX",
+				id:       "A1AM78C64UM0Y8",
+				endpoint: "mws.amazonservices.com.mx",
+			},
+			{
+				region:   "NL",
+				id:       "A1805IZSGTT6HS",
+				endpoint: "mws-eu.amazonservices.com",
+			},
+			{
+				region:   "TR",
+				id:       "A33AVAJ2PDY3EV",
+				endpoint: "mws-eu.amazonservices.com",
+			},
+			{
+				region:   "AE",
+				id:       "A2VIGQ35RCS4UG",
+				endpoint: "mws.amazonservices.ae",
+			},
+			{
+				region:   "AU",
+				id:       "A39IBJ37TRP1C6",
+				endpoint: "mws.amazonservices.com.au",
+			},
+			{
+				region:   "SG",
+				id:       "A19VAU5U5O7RUS",
+				endpoint: "mws-fe.amazonservices.com",
+			},
 		}
 
 		for _, testCase := range testCases {
@@ -100,6 +135,35 @@ func TestNewMarketPlace(t *testing.T) {
 	})
 }
 
+func TestMarketPlacesEndPoint(t *testing.T) {
+	Convey("Marketplaces in the same region", t, func() {
+		endPoint, err := MarketPlacesEndPoint([]string{"A1F83G8C2ARO7P", "A1PA6795UKMFR9"})
+
+		Convey("Shared endpoint returned", func() {
+			So(err, ShouldBeNil)
+			So(endPoint, ShouldEqual, "mws-eu.amazonservices.com")
+		})
+	})
+
+	Convey("Marketplaces in different regions", t, func() {
+		_, err := MarketPlacesEndPoint([]string{"A1F83G8C2ARO7P", "A1VC38T7YXB528"})
+
+		Convey("Mixed marketplaces error returned", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "Marketplaces from different regions: A1F83G8C2ARO7P (mws-eu.amazonservices.com) and A1VC38T7YXB528 (mws.amazonservices.jp)")
+		})
+	})
+
+	Convey("Unknown marketplace", t, func() {
+		_, err := MarketPlacesEndPoint([]string{"Bad"})
+
+		Convey("Marketplace id error returned", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "Invalid marketplace id: Bad")
+		})
+	})
+}
+
 func TestEncoding(t *testing.T) {
 	Convey("Region CN", t, func() {
 		encoding := Encoding("CN")
@@ -108,6 +172,13 @@ func TestEncoding(t *testing.T) {
 		})
 	})
 
+	Convey("Region JP", t, func() {
+		encoding := Encoding("JP")
+		Convey("Shift_JIS encoding returned", func() {
+			So(encoding, ShouldEqual, "Shift_JIS")
+		})
+	})
+
 	Convey("Other region", t, func() {
 		encoding := Encoding("US")
 		Convey("ISO-8859-1 encoding returned", func() {
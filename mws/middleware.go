@@ -0,0 +1,184 @@
+package mws
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Request is the API request passed through the middlewares.
+// The request is signed and built again on each attempt by the innermost
+// 	handler, the middlewares can change the parameters, body and header
+// 	before it.
+type Request struct {
+	// Name of the API, ex: Orders.
+	Name string
+	// Version of the API, ex: 2013-09-01.
+	Version string
+	// Action of the request, ex: ListOrders.
+	Action string
+	// Seller's Amazon id.
+	SellerId string
+	// Marketplace of the client.
+	MarketplaceId string
+	// The structured parameters, signed on send.
+	Params Parameters
+	// Body of the request, nil if the parameters are send as the body.
+	Body *Body
+	// Header added to the http request, ex: the tracing headers.
+	Header http.Header
+	// The signed http request of the last attempt.
+	HTTPRequest *http.Request
+	// Attempts sent.
+	Attempts []Attempt
+
+	// Time waited before the next attempt.
+	delay time.Duration
+}
+
+// Handler send the request and return the response.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wrap the next handler to intercept the requests and responses,
+// 	ex: logging, metrics or fault injection. Ex:
+// 	func(next mws.Handler) mws.Handler {
+// 		return func(ctx context.Context, req *mws.Request) (*mws.Response, error) {
+// 			req.Header.Set("X-Trace-Id", traceID)
+// 			return next(ctx, req)
+// 		}
+// 	}
+type Middleware func(next Handler) Handler
+
+// Chain combine the middlewares into one, the first one is the outermost.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next Handler) Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// RetryMiddleware send the request again when the response or the error is
+// 	retryable by the policy, wait the delay of the policy between the
+// 	attempts. No retry if the policy is nil, or the context is done.
+func RetryMiddleware(policy *RetryPolicy) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			for n := 1; ; n++ {
+				resp, err := next(ctx, req)
+				if policy == nil || n >= policy.MaxAttempts || ctx.Err() != nil {
+					return resp, err
+				}
+				if err != nil && !policy.RetryableError(req, err) {
+					return nil, err
+				}
+				if err == nil {
+					if !policy.Retryable(resp) {
+						return resp, nil
+					}
+					resp.Close()
+				}
+
+				req.delay = policy.Delay(n)
+				if err := SleepContext(ctx, req.delay); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+}
+
+// ThrottleMiddleware wait until the quota of the throttler allow before
+// 	sending the request, and update the quota by the response.
+func ThrottleMiddleware(throttler *Throttler) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			if err := throttler.Wait(ctx, req.Name, req.Action, req.SellerId); err != nil {
+				return nil, err
+			}
+			resp, err := next(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			if quota, ok := resp.Quota(); ok {
+				throttler.Observe(req.Name, req.Action, req.SellerId, quota)
+			}
+			return resp, nil
+		}
+	}
+}
+
+// BodyCaptureMiddleware pass the response body to the capture function,
+// 	ex: to store the responses for audit. The body is copied while the
+// 	caller read it, up to limit bytes, limit <= 0 for no limit. The capture
+// 	function is called once the body is closed, ex: by resp.Close, so the
+// 	large report downloads are not buffered before the caller read them.
+func BodyCaptureMiddleware(limit int64, capture func(req *Request, resp *Response, body []byte)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			resp, err := next(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+
+			resp.Body = &captureBody{
+				ReadCloser: resp.Body,
+				limit:      limit,
+				done: func(body []byte) {
+					capture(req, resp, body)
+				},
+			}
+			return resp, nil
+		}
+	}
+}
+
+// captureBody copy the body read up to the limit, and pass the copy to the
+// 	done function when closed.
+type captureBody struct {
+	io.ReadCloser
+	limit  int64
+	buf    bytes.Buffer
+	done   func(body []byte)
+	closed bool
+}
+
+func (b *captureBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	copied := p[:n]
+	if remain := b.limit - int64(b.buf.Len()); b.limit > 0 && int64(len(copied)) > remain {
+		copied = copied[:remain]
+	}
+	b.buf.Write(copied)
+	return n, err
+}
+
+func (b *captureBody) Close() error {
+	err := b.ReadCloser.Close()
+	if !b.closed {
+		b.closed = true
+		b.done(b.buf.Bytes())
+	}
+	return err
+}
+
+// refreshMiddleware retrieve the credential again and send the request once
+// 	more when MWS return InvalidAccessKeyId, the credential may be rotated.
+func refreshMiddleware(credentials *Credentials) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			resp, err := next(ctx, req)
+			if err != nil || !errors.Is(resp.Error, ErrInvalidAccessKeyId) {
+				return resp, err
+			}
+
+			credentials.Expire()
+			resp.Close()
+			return next(ctx, req)
+		}
+	}
+}
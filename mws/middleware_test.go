@@ -0,0 +1,136 @@
+package mws
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws/mock"
+)
+
+func TestClient_Use(t *testing.T) {
+	server := mock.NewHTTPServer()
+	defer server.Close()
+	server.TimestampTolerance = 0
+	server.SetCredential(testAccessKey, testSecretKey)
+
+	traceIDs := []string{}
+	server.HandleAction("Products", "GetServiceStatus", func(req *mock.Request) *http.Response {
+		traceIDs = append(traceIDs, req.Header.Get("X-Trace-Id"))
+		return mock.NewResponse(200, "<GetServiceStatusResponse/>")
+	})
+
+	newClient := func() *Client {
+		config := testConfig()
+		config.Endpoint = server.URL
+		client, _ := NewClient(config, "2011-10-01", "Products")
+		return client
+	}
+
+	Convey("Middlewares applied in order", t, func() {
+		traceIDs = []string{}
+		calls := []string{}
+		record := func(name string) Middleware {
+			return func(next Handler) Handler {
+				return func(ctx context.Context, req *Request) (*Response, error) {
+					calls = append(calls, name+" "+req.Action)
+					req.Header.Set("X-Trace-Id", "trace")
+					resp, err := next(ctx, req)
+					calls = append(calls, name+" done")
+					return resp, err
+				}
+			}
+		}
+
+		client := newClient()
+		client.Use(record("first"), record("second"))
+		resp, err := client.SendRequest(Parameters{"Action": "GetServiceStatus"})
+		So(err, ShouldBeNil)
+		So(resp.Error, ShouldBeNil)
+		resp.Close()
+
+		So(calls, ShouldResemble, []string{
+			"first GetServiceStatus", "second GetServiceStatus", "second done", "first done",
+		})
+		So(traceIDs, ShouldResemble, []string{"trace"})
+	})
+
+	Convey("Retry the faults injected inside the retry middleware", t, func() {
+		faults := 2
+		inject := func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				if faults > 0 {
+					faults--
+					return NewResponse(mock.NewErrorResponse(503, "RequestThrottled", "Request is throttled")), nil
+				}
+				return next(ctx, req)
+			}
+		}
+
+		client := newClient()
+		client.Use(RetryMiddleware(&RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}), inject)
+		resp, err := client.SendRequest(Parameters{"Action": "GetServiceStatus"})
+		So(err, ShouldBeNil)
+		So(resp.StatusCode, ShouldEqual, 200)
+		So(resp.Attempts, ShouldHaveLength, 1)
+		So(resp.Attempts[0].Delay, ShouldEqual, 2*time.Millisecond)
+		resp.Close()
+	})
+
+	Convey("Capture the response body", t, func() {
+		var captured []byte
+		var request *http.Request
+		client := newClient()
+		client.Use(BodyCaptureMiddleware(0, func(req *Request, resp *Response, body []byte) {
+			captured, request = body, req.HTTPRequest
+		}))
+
+		resp, err := client.SendRequest(Parameters{"Action": "GetServiceStatus"})
+		So(err, ShouldBeNil)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		So(string(body), ShouldEqual, "<GetServiceStatusResponse/>")
+		So(captured, ShouldBeNil)
+
+		resp.Close()
+		So(string(captured), ShouldEqual, "<GetServiceStatusResponse/>")
+		So(request.URL.Path, ShouldEqual, "/Products/2011-10-01")
+	})
+
+	Convey("Capture the response body up to the limit", t, func() {
+		calls := 0
+		var captured []byte
+		client := newClient()
+		client.Use(BodyCaptureMiddleware(10, func(req *Request, resp *Response, body []byte) {
+			calls++
+			captured = body
+		}))
+
+		resp, err := client.SendRequest(Parameters{"Action": "GetServiceStatus"})
+		So(err, ShouldBeNil)
+		resp.Close()
+		resp.Body.Close()
+
+		So(calls, ShouldEqual, 1)
+		So(string(captured), ShouldEqual, "<GetServic")
+	})
+
+	Convey("Throttle in the chain", t, func() {
+		throttler := NewThrottler()
+		throttler.SetQuota("Products", "GetServiceStatus", RequestQuota{MaxRequests: 1, RestoreRate: time.Hour})
+		client := newClient()
+		client.Use(ThrottleMiddleware(throttler))
+
+		resp, err := client.SendRequest(Parameters{"Action": "GetServiceStatus"})
+		So(err, ShouldBeNil)
+		resp.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = client.SendRequestContext(ctx, Parameters{"Action": "GetServiceStatus"})
+		So(err, ShouldNotBeNil)
+	})
+}
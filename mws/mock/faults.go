@@ -0,0 +1,68 @@
+package mock
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Faults the faults injected to the simulated MWS responses.
+// The rates are the probability of the fault, from 0 to 1.
+type Faults struct {
+	// Rate of the requests failed with 500 InternalError.
+	ErrorRate float64
+	// Rate of the responses delayed by Latency.
+	SlowRate float64
+	Latency  time.Duration
+	// Rate of the responses with the body truncated, the connection is
+	// 	closed after half of the body is sent.
+	TruncateRate float64
+}
+
+// SimulateFaults inject the faults to the simulated MWS responses.
+// The requests rejected by the validation or throttling are not affected.
+func (ms *Server) SimulateFaults(faults Faults) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.faults = faults
+}
+
+// fault decide whether or not the fault happen by the rate.
+func (ms *Server) fault(rate func(Faults) float64) bool {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	r := rate(ms.faults)
+	return r > 0 && ms.random.Float64() < r
+}
+
+// injectError return a 500 InternalError response by the ErrorRate.
+func (ms *Server) injectError() *http.Response {
+	if !ms.fault(func(f Faults) float64 { return f.ErrorRate }) {
+		return nil
+	}
+	return NewErrorResponse(500, CodeInternalError, "We encountered an internal error. Please try again.")
+}
+
+// injectLatency delay the response by the SlowRate, until the request is cancelled.
+func (ms *Server) injectLatency(ctx context.Context) {
+	if !ms.fault(func(f Faults) float64 { return f.SlowRate }) {
+		return
+	}
+
+	ms.mu.Lock()
+	latency := ms.faults.Latency
+	ms.mu.Unlock()
+
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// injectTruncation decide whether or not to truncate the response body by
+// 	the TruncateRate.
+func (ms *Server) injectTruncation() bool {
+	return ms.fault(func(f Faults) float64 { return f.TruncateRate })
+}
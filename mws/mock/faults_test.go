@@ -0,0 +1,64 @@
+package mock
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+)
+
+func TestServer_SimulateFaults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := testClient(server, "Orders", "2013-09-01")
+
+	Convey("Fail the requests with InternalError", t, func() {
+		server.SimulateFaults(Faults{ErrorRate: 1})
+		defer server.SimulateFaults(Faults{})
+
+		resp, err := client.SendRequest(mws.Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.StatusCode, ShouldEqual, 500)
+		So(errors.Is(resp.Error, mws.ErrInternalError), ShouldBeTrue)
+	})
+
+	Convey("Delay the responses", t, func() {
+		server.SimulateFaults(Faults{SlowRate: 1, Latency: 100 * time.Millisecond})
+		defer server.SimulateFaults(Faults{})
+
+		start := time.Now()
+		resp, err := client.SendRequest(mws.Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.Error, ShouldBeNil)
+		So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 100*time.Millisecond)
+	})
+
+	Convey("Truncate the response bodies", t, func() {
+		server.SimulateFaults(Faults{TruncateRate: 1})
+		defer server.SimulateFaults(Faults{})
+
+		resp, err := client.SendRequest(mws.Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		_, err = ioutil.ReadAll(resp.Body)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("No faults by default", t, func() {
+		resp, err := client.SendRequest(mws.Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		body, err := ioutil.ReadAll(resp.Body)
+		So(err, ShouldBeNil)
+		So(string(body), ShouldContainSubstring, "</GetOrderResponse>")
+	})
+}
@@ -0,0 +1,219 @@
+package mock
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// feedSubmissionInfo the FeedSubmissionInfo element.
+type feedSubmissionInfo struct {
+	FeedSubmissionId        string
+	FeedType                string
+	SubmittedDate           string
+	FeedProcessingStatus    string
+	StartedProcessingDate   string `xml:",omitempty"`
+	CompletedProcessingDate string `xml:",omitempty"`
+}
+
+// feedSubmission is a feed submission simulated by the server.
+type feedSubmission struct {
+	id          string
+	feedType    string
+	contentType string
+	content     []byte
+	progress    *progress
+
+	// Set once the submission is done.
+	result []byte
+}
+
+// info return the FeedSubmissionInfo of the feed submission.
+func (fs *feedSubmission) info() feedSubmissionInfo {
+	return feedSubmissionInfo{
+		FeedSubmissionId:        fs.id,
+		FeedType:                fs.feedType,
+		SubmittedDate:           formatTime(fs.progress.submitted),
+		FeedProcessingStatus:    fs.progress.status,
+		StartedProcessingDate:   formatTime(fs.progress.started),
+		CompletedProcessingDate: formatTime(fs.progress.completed),
+	}
+}
+
+// SimulateFeeds simulate the feed submissions with states, the feed
+// 	submissions move through the statuses by the lifecycle.
+// The actions handled:
+// 	SubmitFeed - create a feed submission in _SUBMITTED_ status, the feed
+// 		is rejected if it not match the ContentMD5Value.
+// 	GetFeedSubmissionList - count as a poll of the listed feed submissions.
+// 	CancelFeedSubmissions - cancel the listed feed submissions in _SUBMITTED_ status.
+// 	GetFeedSubmissionResult - return the processing report once the
+// 		submission is done, with the Content-MD5 header.
+// The processing report is the one set by SetFeedResult for the feed type,
+// 	or a report of all messages processed successfully.
+func (ms *Server) SimulateFeeds(lifecycle Lifecycle) {
+	ms.HandleAction("Feeds", "SubmitFeed", func(req *Request) *http.Response {
+		return ms.submitFeed(req, lifecycle)
+	})
+	ms.HandleAction("Feeds", "GetFeedSubmissionList", ms.getFeedSubmissionList)
+	ms.HandleAction("Feeds", "CancelFeedSubmissions", ms.cancelFeedSubmissions)
+	ms.HandleAction("Feeds", "GetFeedSubmissionResult", ms.getFeedSubmissionResult)
+}
+
+// SetFeedResult set the processing report for the feed type. The feed
+// 	submissions of the type done after that return the report.
+func (ms *Server) SetFeedResult(feedType string, result []byte) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.feedResults[feedType] = result
+}
+
+// SubmittedFeed return the content and content type of the feed submitted.
+func (ms *Server) SubmittedFeed(feedSubmissionID string) ([]byte, string, bool) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	for _, fs := range ms.feedSubmissions {
+		if fs.id == feedSubmissionID {
+			return fs.content, fs.contentType, true
+		}
+	}
+	return nil, "", false
+}
+
+// submitFeed handle the SubmitFeed action.
+func (ms *Server) submitFeed(req *Request, lifecycle Lifecycle) *http.Response {
+	feedType := req.Params.Get("FeedType")
+	if feedType == "" {
+		return NewErrorResponse(400, CodeInvalidParameterValue, "FeedType is required")
+	}
+	if md5Value := req.Params.Get("ContentMD5Value"); md5Value != "" && md5Value != contentMD5(req.Body) {
+		return NewErrorResponse(400, CodeContentMD5DoesNotMatch,
+			"the Content-MD5 HTTP header you passed for your feed did not match the Content-MD5 we calculated for your feed")
+	}
+
+	ms.mu.Lock()
+	fs := &feedSubmission{
+		id:          ms.nextID(),
+		feedType:    feedType,
+		contentType: req.Header.Get("Content-Type"),
+		content:     req.Body,
+		progress:    newProgress(lifecycle),
+	}
+	ms.feedSubmissions = append(ms.feedSubmissions, fs)
+	info := fs.info()
+	ms.mu.Unlock()
+
+	return NewResultResponse(req, struct {
+		FeedSubmissionInfo feedSubmissionInfo
+	}{info})
+}
+
+// getFeedSubmissionList handle the GetFeedSubmissionList action.
+func (ms *Server) getFeedSubmissionList(req *Request) *http.Response {
+	ids := listParam(req.Params, "FeedSubmissionIdList.Id")
+	types := listParam(req.Params, "FeedTypeList.Type")
+	statuses := listParam(req.Params, "FeedProcessingStatusList.Status")
+
+	ms.mu.Lock()
+	infos := []feedSubmissionInfo{}
+	for _, fs := range ms.feedSubmissions {
+		if !matchList(ids, fs.id) || !matchList(types, fs.feedType) {
+			continue
+		}
+		if fs.progress.poll() {
+			fs.result = ms.feedResult(fs)
+		}
+		if matchList(statuses, fs.progress.status) {
+			infos = append(infos, fs.info())
+		}
+	}
+	ms.mu.Unlock()
+
+	return NewResultResponse(req, struct {
+		HasNext            bool
+		FeedSubmissionInfo []feedSubmissionInfo
+	}{false, infos})
+}
+
+// cancelFeedSubmissions handle the CancelFeedSubmissions action.
+func (ms *Server) cancelFeedSubmissions(req *Request) *http.Response {
+	ids := listParam(req.Params, "FeedSubmissionIdList.Id")
+	types := listParam(req.Params, "FeedTypeList.Type")
+
+	ms.mu.Lock()
+	infos := []feedSubmissionInfo{}
+	for _, fs := range ms.feedSubmissions {
+		if matchList(ids, fs.id) && matchList(types, fs.feedType) && fs.progress.cancel() {
+			infos = append(infos, fs.info())
+		}
+	}
+	ms.mu.Unlock()
+
+	return NewResultResponse(req, struct {
+		Count              int
+		FeedSubmissionInfo []feedSubmissionInfo
+	}{len(infos), infos})
+}
+
+// getFeedSubmissionResult handle the GetFeedSubmissionResult action.
+func (ms *Server) getFeedSubmissionResult(req *Request) *http.Response {
+	id := req.Params.Get("FeedSubmissionId")
+
+	ms.mu.Lock()
+	var fs *feedSubmission
+	for _, submission := range ms.feedSubmissions {
+		if submission.id == id {
+			fs = submission
+			break
+		}
+	}
+	var result []byte
+	if fs != nil {
+		result = fs.result
+	}
+	ms.mu.Unlock()
+
+	if fs == nil {
+		return NewErrorResponse(400, CodeInvalidParameterValue,
+			fmt.Sprintf("Feed submission %v not found", id))
+	}
+	if result == nil {
+		return NewErrorResponse(404, CodeFeedProcessingResultNotReady,
+			fmt.Sprintf("Feed Submission Result is not ready for Feed %v", id))
+	}
+
+	resp := NewResponse(200, string(result))
+	resp.Header.Set("Content-Type", "text/xml")
+	resp.Header.Set("Content-MD5", contentMD5(result))
+	return resp
+}
+
+// feedResult return the processing report of the feed submission, must be
+// 	called with the lock held.
+func (ms *Server) feedResult(fs *feedSubmission) []byte {
+	if result, ok := ms.feedResults[fs.feedType]; ok {
+		return result
+	}
+
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<AmazonEnvelope xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:noNamespaceSchemaLocation="amzn-envelope.xsd">
+  <Header>
+    <DocumentVersion>1.02</DocumentVersion>
+    <MerchantIdentifier>M_EXAMPLE_123456</MerchantIdentifier>
+  </Header>
+  <MessageType>ProcessingReport</MessageType>
+  <Message>
+    <MessageID>1</MessageID>
+    <ProcessingReport>
+      <DocumentTransactionID>%v</DocumentTransactionID>
+      <StatusCode>Complete</StatusCode>
+      <ProcessingSummary>
+        <MessagesProcessed>1</MessagesProcessed>
+        <MessagesSuccessful>1</MessagesSuccessful>
+        <MessagesWithError>0</MessagesWithError>
+        <MessagesWithWarning>0</MessagesWithWarning>
+      </ProcessingSummary>
+    </ProcessingReport>
+  </Message>
+</AmazonEnvelope>
+`, fs.id))
+}
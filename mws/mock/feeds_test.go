@@ -0,0 +1,102 @@
+package mock
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+	"github.com/svvu/gomws/mws/feeds"
+)
+
+func TestServer_SimulateFeeds(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SimulateFeeds(Lifecycle{Polls: 1})
+	client, _ := feeds.NewClient(mws.Config{
+		SellerId:  "SellerID",
+		AuthToken: "AuthToken",
+		Region:    "US",
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+	})
+	client.Host = server.Host()
+	client.Transport = NoVerifyTransport()
+
+	submissionInfo := func(resp *mws.Response, err error) feeds.FeedSubmissionInfo {
+		So(err, ShouldBeNil)
+		defer resp.Close()
+		info := feeds.FeedSubmissionInfo{}
+		So(resp.DecodeResult("FeedSubmissionInfo", &info), ShouldBeNil)
+		return info
+	}
+
+	Convey("Process the feed submission through the statuses", t, func() {
+		info := submissionInfo(client.SubmitFeed("_POST_PRODUCT_DATA_", []byte("<AmazonEnvelope/>")))
+		So(info.FeedProcessingStatus, ShouldEqual, StatusSubmitted)
+
+		content, contentType, ok := server.SubmittedFeed(info.FeedSubmissionId)
+		So(ok, ShouldBeTrue)
+		So(string(content), ShouldEqual, "<AmazonEnvelope/>")
+		So(contentType, ShouldEqual, "text/xml; charset=UTF-8")
+
+		resp, err := client.GetFeedSubmissionResult(info.FeedSubmissionId)
+		So(err, ShouldBeNil)
+		So(resp.StatusCode, ShouldEqual, 404)
+		So(errors.Is(resp.Error, mws.ErrorCode(CodeFeedProcessingResultNotReady)), ShouldBeTrue)
+		resp.Close()
+
+		params := mws.Parameters{"FeedSubmissionIdList": []string{info.FeedSubmissionId}}
+		info = submissionInfo(client.GetFeedSubmissionList(params))
+		So(info.FeedProcessingStatus, ShouldEqual, StatusInProgress)
+		info = submissionInfo(client.GetFeedSubmissionList(params))
+		So(info.FeedProcessingStatus, ShouldEqual, StatusDone)
+		So(info.CompletedProcessingDate, ShouldNotBeEmpty)
+
+		resp, err = client.GetFeedSubmissionResult(info.FeedSubmissionId)
+		So(err, ShouldBeNil)
+		defer resp.Close()
+		So(resp.Error, ShouldBeNil)
+		result, err := ioutil.ReadAll(resp.VerifiedBody())
+		So(err, ShouldBeNil)
+		So(string(result), ShouldContainSubstring, "<DocumentTransactionID>"+info.FeedSubmissionId+"</DocumentTransactionID>")
+	})
+
+	Convey("Return the processing report set for the feed type", t, func() {
+		server.SetFeedResult("_POST_INVENTORY_AVAILABILITY_DATA_", []byte("<ProcessingReport/>"))
+		info := submissionInfo(client.SubmitFeed("_POST_INVENTORY_AVAILABILITY_DATA_", []byte("<AmazonEnvelope/>")))
+
+		params := mws.Parameters{"FeedSubmissionIdList": []string{info.FeedSubmissionId}}
+		submissionInfo(client.GetFeedSubmissionList(params))
+		submissionInfo(client.GetFeedSubmissionList(params))
+
+		resp, err := client.GetFeedSubmissionResult(info.FeedSubmissionId)
+		So(err, ShouldBeNil)
+		defer resp.Close()
+		result, _ := ioutil.ReadAll(resp.Body)
+		So(string(result), ShouldEqual, "<ProcessingReport/>")
+	})
+
+	Convey("Cancel the submitted feed submission", t, func() {
+		info := submissionInfo(client.SubmitFeed("_POST_PRODUCT_DATA_", []byte("<AmazonEnvelope/>")))
+
+		info = submissionInfo(client.CancelFeedSubmissions(mws.Parameters{
+			"FeedSubmissionIdList": []string{info.FeedSubmissionId},
+		}))
+		So(info.FeedProcessingStatus, ShouldEqual, StatusCancelled)
+	})
+
+	Convey("Reject the feed not match the ContentMD5Value", t, func() {
+		resp, err := client.SendBodyRequest(mws.Parameters{
+			"Action":          "SubmitFeed",
+			"FeedType":        "_POST_PRODUCT_DATA_",
+			"ContentMD5Value": "wrong",
+		}, mws.Body{Content: []byte("<AmazonEnvelope/>")})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.StatusCode, ShouldEqual, 400)
+		So(errors.Is(resp.Error, mws.ErrorCode(CodeContentMD5DoesNotMatch)), ShouldBeTrue)
+	})
+}
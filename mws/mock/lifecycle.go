@@ -0,0 +1,104 @@
+package mock
+
+import (
+	"time"
+)
+
+// Processing status of the report requests and feed submissions.
+const (
+	StatusSubmitted  = "_SUBMITTED_"
+	StatusInProgress = "_IN_PROGRESS_"
+	StatusCancelled  = "_CANCELLED_"
+	StatusDone       = "_DONE_"
+	StatusDoneNoData = "_DONE_NO_DATA_"
+)
+
+// Lifecycle control how the report requests and feed submissions move
+// 	through _SUBMITTED_ -> _IN_PROGRESS_ -> _DONE_.
+// The status move to the next one when the status lasted for Duration and
+// 	has been polled by Polls times, zero value to skip the condition.
+// A zero Lifecycle move to _DONE_ on the first poll.
+type Lifecycle struct {
+	// Time to stay in each status.
+	Duration time.Duration
+	// Number of polls to stay in each status, the list requests including
+	// 	the report request or feed submission are counted as polls.
+	Polls int
+}
+
+// progress is the processing progress of a report request or feed submission.
+type progress struct {
+	lifecycle Lifecycle
+	status    string
+	since     time.Time
+	polls     int
+
+	submitted time.Time
+	started   time.Time
+	completed time.Time
+}
+
+// newProgress create a progress in _SUBMITTED_ status.
+func newProgress(lifecycle Lifecycle) *progress {
+	now := time.Now()
+	return &progress{lifecycle: lifecycle, status: StatusSubmitted, since: now, submitted: now}
+}
+
+// poll count a poll and move the status forward by the lifecycle.
+// Return true if the status moved to _DONE_ by the poll.
+func (p *progress) poll() bool {
+	if p.finished() {
+		return false
+	}
+
+	p.polls++
+	for !p.finished() && p.ready() {
+		// Without the polls condition, the statuses move by time only, so
+		// 	a late poll can move through multiple statuses.
+		if p.lifecycle.Polls > 0 {
+			p.since = time.Now()
+		} else {
+			p.since = p.since.Add(p.lifecycle.Duration)
+		}
+		p.polls = 0
+		if p.status == StatusSubmitted {
+			p.status = StatusInProgress
+			p.started = p.since
+		} else {
+			p.status = StatusDone
+			p.completed = p.since
+		}
+	}
+	return p.status == StatusDone
+}
+
+// ready check whether or not the current status can move to the next one.
+func (p *progress) ready() bool {
+	if p.lifecycle.Polls > 0 && p.polls < p.lifecycle.Polls {
+		return false
+	}
+	return time.Since(p.since) >= p.lifecycle.Duration
+}
+
+// cancel cancel the progress, only the submitted one can be cancelled.
+func (p *progress) cancel() bool {
+	if p.status != StatusSubmitted {
+		return false
+	}
+	p.status = StatusCancelled
+	p.completed = time.Now()
+	return true
+}
+
+// finished check whether or not the progress is finished.
+func (p *progress) finished() bool {
+	return p.status != StatusSubmitted && p.status != StatusInProgress
+}
+
+// formatTime format the time in MWS format, empty for zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,400 @@
+package mock
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MWS error codes returned by the server.
+const (
+	CodeInvalidParameterValue = "InvalidParameterValue"
+	CodeInvalidAccessKeyId    = "InvalidAccessKeyId"
+	CodeSignatureDoesNotMatch = "SignatureDoesNotMatch"
+	CodeInvalidAddress        = "InvalidAddress"
+	CodeInternalError         = "InternalError"
+	CodeRequestThrottled      = "RequestThrottled"
+	CodeQuotaExceeded         = "QuotaExceeded"
+
+	CodeContentMD5DoesNotMatch       = "ContentMD5DoesNotMatch"
+	CodeFeedProcessingResultNotReady = "FeedProcessingResultNotReady"
+)
+
+// Request is the MWS request received by the server.
+type Request struct {
+	*http.Request
+	// API name and version in the path, ex: Orders and 2013-09-01.
+	Name    string
+	Version string
+	// The Action parameter.
+	Action string
+	// The parameters in the form body and query string.
+	Params url.Values
+	// The body of the body-carrying requests, ex: the feed of SubmitFeed.
+	Body []byte
+}
+
+// ActionHandler handle the request of an action.
+type ActionHandler func(req *Request) *http.Response
+
+// SetCredential set the credential to verify the signature of the requests.
+// Requests with other AWSAccessKeyId are rejected once a credential is set.
+func (ms *Server) SetCredential(accessKey, secretKey string) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.credentials[accessKey] = secretKey
+}
+
+// HandleAction set the handler for the action of the API, ex: "Orders", "ListOrders".
+// The handler take precedence over the exampleResponses.
+func (ms *Server) HandleAction(name, action string, handler ActionHandler) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.handlers[name+"/"+action] = handler
+}
+
+// SetExampleDir set the directory of the example responses for the API.
+// By default, the exampleResponses directory of the API package is used,
+// 	ex: mws/orders/exampleResponses for Orders, see SetExampleRoot.
+// The example response for an action is the file <Action>.xml or <Action>.txt.
+func (ms *Server) SetExampleDir(name, dir string) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.exampleDirs[name] = dir
+}
+
+// SetExampleRoot set the mws directory to look for the exampleResponses of
+// 	the API packages, ex: <root>/orders/exampleResponses for Orders.
+// By default, the mws directory of the mock source file is used, which is
+// 	not available when built with -trimpath, vendored, or in a module cache
+// 	without the example responses, set the root explicitly in these cases.
+func (ms *Server) SetExampleRoot(root string) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.exampleRoot = root
+}
+
+// serveMWS simulate the MWS for the request.
+func (ms *Server) serveMWS(r *http.Request) *http.Response {
+	req, err := parseRequest(r)
+	if err != nil {
+		return withMetadata(NewErrorResponse(400, CodeInvalidParameterValue, err.Error()))
+	}
+
+	if errResp := ms.validate(req); errResp != nil {
+		return withMetadata(errResp)
+	}
+
+	header, resp := ms.throttle(req)
+	if resp == nil {
+		resp = ms.injectError()
+	}
+	if resp == nil {
+		resp = ms.handle(req)
+	}
+	resp = withMetadata(resp)
+	for k, v := range header {
+		resp.Header[k] = v
+	}
+	return resp
+}
+
+// handle serve the action handler, or the example response of the action.
+func (ms *Server) handle(req *Request) *http.Response {
+	ms.mu.Lock()
+	handler, ok := ms.handlers[req.Name+"/"+req.Action]
+	ms.mu.Unlock()
+	if ok {
+		return handler(req)
+	}
+	return ms.exampleResponse(req)
+}
+
+// parseRequest parse the parameters and body of the request.
+func parseRequest(r *http.Request) (*Request, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &Request{Request: r, Params: r.Form, Body: body, Action: r.Form.Get("Action")}
+	// The name and version are the last segments, the path may have the
+	// 	prefix of the endpoint, ex: /proxy/Orders/2013-09-01.
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) > 1 {
+		req.Name, req.Version = parts[len(parts)-2], parts[len(parts)-1]
+	} else {
+		req.Name = parts[0]
+	}
+	return req, nil
+}
+
+// validate check the required parameters, timestamp and signature.
+// Return the error response if the request is invalid.
+func (ms *Server) validate(req *Request) *http.Response {
+	if req.Params.Get("SellerId") == "" && req.Params.Get("Merchant") == "" {
+		return NewErrorResponse(400, CodeInvalidParameterValue, "Either SellerId or Merchant must be provided")
+	}
+
+	if ms.TimestampTolerance > 0 {
+		timestamp, err := time.Parse(time.RFC3339, req.Params.Get("Timestamp"))
+		if err != nil {
+			return NewErrorResponse(400, CodeInvalidParameterValue,
+				fmt.Sprintf("Timestamp %v is not valid", req.Params.Get("Timestamp")))
+		}
+		skew := time.Since(timestamp)
+		if skew > ms.TimestampTolerance || skew < -ms.TimestampTolerance {
+			return NewErrorResponse(400, CodeInvalidParameterValue,
+				fmt.Sprintf("Request has expired. Timestamp date is %v", req.Params.Get("Timestamp")))
+		}
+	}
+
+	ms.mu.Lock()
+	verify := len(ms.credentials) > 0
+	secretKey, ok := ms.credentials[req.Params.Get("AWSAccessKeyId")]
+	ms.mu.Unlock()
+	if !verify {
+		return nil
+	}
+	if !ok {
+		return NewErrorResponse(403, CodeInvalidAccessKeyId,
+			"The AWS Access Key Id you provided does not exist in our records.")
+	}
+	if req.Params.Get("SignatureMethod") != "HmacSHA256" || req.Params.Get("SignatureVersion") != "2" {
+		return NewErrorResponse(400, CodeInvalidParameterValue, "Only HmacSHA256 signature version 2 is supported")
+	}
+	if !hmac.Equal([]byte(req.Params.Get("Signature")), []byte(signature(req, secretKey))) {
+		return NewErrorResponse(403, CodeSignatureDoesNotMatch,
+			"The request signature we calculated does not match the signature you provided.")
+	}
+	return nil
+}
+
+// signature calculate the SigV2 signature of the request.
+func signature(req *Request, secretKey string) string {
+	params := url.Values{}
+	for k, v := range req.Params {
+		if k != "Signature" {
+			params[k] = v
+		}
+	}
+
+	stringToSign := req.Method + "\n" +
+		req.Host + "\n" +
+		req.URL.Path + "\n" +
+		strings.Replace(params.Encode(), "+", "%20", -1)
+
+	hash := hmac.New(sha256.New, []byte(secretKey))
+	hash.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil))
+}
+
+// exampleResponse serve the example response of the action.
+func (ms *Server) exampleResponse(req *Request) *http.Response {
+	for _, dir := range ms.exampleDirsOf(req.Name) {
+		for _, ext := range []string{".xml", ".txt"} {
+			body, err := ioutil.ReadFile(filepath.Join(dir, req.Action+ext))
+			if err != nil {
+				continue
+			}
+			resp := NewResponse(200, string(body))
+			if ext == ".xml" {
+				resp.Header.Set("Content-Type", "text/xml")
+			} else {
+				resp.Header.Set("Content-Type", "text/plain;charset=UTF-8")
+			}
+			return resp
+		}
+	}
+
+	return NewErrorResponse(404, CodeInvalidAddress,
+		fmt.Sprintf("Action %v is not valid for API %v", req.Action, req.Name))
+}
+
+// exampleDirsOf return the directories to look for the example responses.
+func (ms *Server) exampleDirsOf(name string) []string {
+	ms.mu.Lock()
+	dir, ok := ms.exampleDirs[name]
+	root := ms.exampleRoot
+	ms.mu.Unlock()
+	if ok {
+		return []string{dir}
+	}
+
+	if root == "" {
+		root = sourceRoot()
+	}
+	if root == "" {
+		return nil
+	}
+	dirs := []string{filepath.Join(root, "exampleResponses")}
+	if name != "" {
+		apiDir := filepath.Join(root, strings.ToLower(name), "exampleResponses")
+		if _, err := os.Stat(apiDir); err == nil {
+			dirs = append([]string{apiDir}, dirs...)
+		}
+	}
+	return dirs
+}
+
+// sourceRoot return the mws directory of the mock source file, empty if the
+// 	source is not available.
+func sourceRoot() string {
+	_, file, _, ok := runtime.Caller(0)
+	if !ok {
+		return ""
+	}
+	root := filepath.Dir(filepath.Dir(file))
+	if _, err := os.Stat(root); err != nil {
+		return ""
+	}
+	return root
+}
+
+// NewErrorResponse create a MWS error response.
+// The error type is Sender for 4xx status, Receiver for others.
+func NewErrorResponse(statusCode int, code, message string) *http.Response {
+	errorType := "Receiver"
+	if statusCode >= 400 && statusCode < 500 {
+		errorType = "Sender"
+	}
+
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0"?>` + "\n")
+	body.WriteString(`<ErrorResponse xmlns="https://mws.amazonservices.com/">` + "\n")
+	body.WriteString("  <Error>\n")
+	fmt.Fprintf(&body, "    <Type>%v</Type>\n", errorType)
+	fmt.Fprintf(&body, "    <Code>%v</Code>\n", escape(code))
+	fmt.Fprintf(&body, "    <Message>%v</Message>\n", escape(message))
+	body.WriteString("  </Error>\n")
+	fmt.Fprintf(&body, "  <RequestID>%v</RequestID>\n", newRequestID())
+	body.WriteString("</ErrorResponse>\n")
+
+	resp := NewResponse(statusCode, body.String())
+	resp.Header.Set("Content-Type", "text/xml")
+	return resp
+}
+
+// NewResultResponse create a MWS response of the action with the result.
+// The result is encoded by encoding/xml as the <Action>Result element, ex:
+// 	<RequestReportResponse xmlns="http://mws.amazonaws.com/doc/2009-01-01/">
+// 		<RequestReportResult>result</RequestReportResult>
+// 		<ResponseMetadata><RequestId>id</RequestId></ResponseMetadata>
+// 	</RequestReportResponse>
+func NewResultResponse(req *Request, result interface{}) *http.Response {
+	requestID := newRequestID()
+	namespace := "https://mws.amazonservices.com/" + req.Name + "/" + req.Version
+	if req.Name == "Reports" || req.Name == "Feeds" {
+		namespace = "http://mws.amazonaws.com/doc/" + req.Version + "/"
+	}
+
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&body)
+	encoder.Indent("", "  ")
+	response := xml.StartElement{
+		Name: xml.Name{Local: req.Action + "Response"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: namespace}},
+	}
+	metadata := struct {
+		RequestId string
+	}{requestID}
+
+	err := encoder.EncodeToken(response)
+	if err == nil {
+		err = encoder.EncodeElement(result, xml.StartElement{Name: xml.Name{Local: req.Action + "Result"}})
+	}
+	if err == nil {
+		err = encoder.EncodeElement(metadata, xml.StartElement{Name: xml.Name{Local: "ResponseMetadata"}})
+	}
+	if err == nil {
+		err = encoder.EncodeToken(response.End())
+	}
+	if err == nil {
+		err = encoder.Flush()
+	}
+	if err != nil {
+		return NewErrorResponse(500, CodeInternalError, err.Error())
+	}
+
+	resp := NewResponse(200, body.String())
+	resp.Header.Set("Content-Type", "text/xml")
+	resp.Header.Set("x-mws-request-id", requestID)
+	return resp
+}
+
+// listParam return the values of the structured list parameter,
+// 	ex: ReportRequestIdList.Id.1, ReportRequestIdList.Id.2 for ReportRequestIdList.Id.
+func listParam(params url.Values, key string) []string {
+	values := []string{}
+	for i := 1; ; i++ {
+		value, ok := params[fmt.Sprintf("%v.%v", key, i)]
+		if !ok {
+			return values
+		}
+		values = append(values, value...)
+	}
+}
+
+// matchList check whether or not the value in the list, empty list match all.
+func matchList(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// withMetadata add the x-mws-request-id and x-mws-timestamp headers.
+func withMetadata(resp *http.Response) *http.Response {
+	if resp.Header == nil {
+		resp.Header = http.Header{}
+	}
+	if resp.Header.Get("x-mws-request-id") == "" {
+		resp.Header.Set("x-mws-request-id", newRequestID())
+	}
+	resp.Header.Set("x-mws-timestamp", time.Now().UTC().Format(time.RFC3339))
+	return resp
+}
+
+// nextID generate the next id for the report requests, reports and feed
+// 	submissions, must be called with the lock held.
+func (ms *Server) nextID() string {
+	ms.sequence++
+	return strconv.FormatInt(50000000000+ms.sequence, 10)
+}
+
+// newRequestID generate a random request id in uuid format.
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// escape escape the xml special characters.
+func escape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
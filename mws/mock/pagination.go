@@ -0,0 +1,230 @@
+package mock
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Dataset is the data served page by page by the list actions.
+// The elements are encoded by encoding/xml, ex: orders.Order, orders.OrderItem
+// 	and reports.ReportInfo. The filters of the list actions are not applied.
+type Dataset struct {
+	// Orders served by ListOrders as the Order elements.
+	Orders []interface{}
+	// Order items by the AmazonOrderId, served by ListOrderItems as the
+	// 	OrderItem elements.
+	OrderItems map[string][]interface{}
+	// Reports served by GetReportList as the ReportInfo elements.
+	Reports []interface{}
+
+	// Number of elements per page if not specified in the request by
+	// 	MaxResultsPerPage or MaxCount.
+	// Default to 100 for the orders and order items, 10 for the reports.
+	PageSize int
+	// Time before the NextToken expired. Default to 15 minutes.
+	TokenExpiry time.Duration
+}
+
+// cursor is the position of the next page issued with a NextToken.
+type cursor struct {
+	action  string
+	orderID string
+	offset  int
+	size    int
+	expires time.Time
+}
+
+// generatedOrder is the Order element generated by GenerateOrders.
+type generatedOrder struct {
+	AmazonOrderId  string
+	PurchaseDate   string
+	LastUpdateDate string
+	OrderStatus    string
+	OrderTotal     struct {
+		CurrencyCode string
+		Amount       string
+	}
+	MarketplaceId string
+}
+
+// GenerateOrders generate n orders for the Dataset, the AmazonOrderIds are
+// 	000-0000000-0000001, 000-0000000-0000002 and so on, purchased one
+// 	minute apart from 2017-01-01.
+func GenerateOrders(n int) []interface{} {
+	start := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	orders := make([]interface{}, n)
+	for i := range orders {
+		order := generatedOrder{
+			AmazonOrderId:  fmt.Sprintf("000-0000000-%07d", i+1),
+			PurchaseDate:   formatTime(start.Add(time.Duration(i) * time.Minute)),
+			LastUpdateDate: formatTime(start.Add(time.Duration(i) * time.Minute)),
+			OrderStatus:    "Unshipped",
+			MarketplaceId:  "ATVPDKIKX0DER",
+		}
+		order.OrderTotal.CurrencyCode = "USD"
+		order.OrderTotal.Amount = fmt.Sprintf("%v.99", i+1)
+		orders[i] = order
+	}
+	return orders
+}
+
+// LoadDataset serve the dataset page by page.
+// The actions handled, for the data set in the dataset:
+// 	ListOrders, ListOrdersByNextToken - the orders.
+// 	ListOrderItems, ListOrderItemsByNextToken - the order items of the order.
+// 	GetReportList, GetReportListByNextToken - the reports.
+// The NextToken is issued when more pages remained, the ByNextToken actions
+// 	reject the unknown or expired tokens with 400 InvalidParameterValue.
+func (ms *Server) LoadDataset(dataset Dataset) {
+	if dataset.TokenExpiry <= 0 {
+		dataset.TokenExpiry = 15 * time.Minute
+	}
+
+	ms.mu.Lock()
+	ms.dataset = dataset
+	ms.cursors = map[string]*cursor{}
+	ms.mu.Unlock()
+
+	if dataset.Orders != nil {
+		ms.HandleAction("Orders", "ListOrders", ms.listOrders)
+		ms.HandleAction("Orders", "ListOrdersByNextToken", ms.listOrders)
+	}
+	if dataset.OrderItems != nil {
+		ms.HandleAction("Orders", "ListOrderItems", ms.listOrderItems)
+		ms.HandleAction("Orders", "ListOrderItemsByNextToken", ms.listOrderItems)
+	}
+	if dataset.Reports != nil {
+		ms.HandleAction("Reports", "GetReportList", ms.listReports)
+		ms.HandleAction("Reports", "GetReportListByNextToken", ms.listReports)
+	}
+}
+
+// listOrders handle the ListOrders and ListOrdersByNextToken actions.
+func (ms *Server) listOrders(req *Request) *http.Response {
+	c, errResp := ms.cursor(req, "ListOrders", "MaxResultsPerPage", 100)
+	if errResp != nil {
+		return errResp
+	}
+
+	ms.mu.Lock()
+	page, nextToken := ms.page(c, ms.dataset.Orders)
+	ms.mu.Unlock()
+
+	return NewResultResponse(req, struct {
+		NextToken         string        `xml:",omitempty"`
+		CreatedBefore     string        `xml:",omitempty"`
+		LastUpdatedBefore string        `xml:",omitempty"`
+		Orders            []interface{} `xml:"Orders>Order"`
+	}{nextToken, req.Params.Get("CreatedBefore"), req.Params.Get("LastUpdatedBefore"), page})
+}
+
+// listOrderItems handle the ListOrderItems and ListOrderItemsByNextToken actions.
+func (ms *Server) listOrderItems(req *Request) *http.Response {
+	c, errResp := ms.cursor(req, "ListOrderItems", "MaxResultsPerPage", 100)
+	if errResp != nil {
+		return errResp
+	}
+
+	ms.mu.Lock()
+	items, ok := ms.dataset.OrderItems[c.orderID]
+	page, nextToken := ms.page(c, items)
+	ms.mu.Unlock()
+
+	if !ok {
+		return NewErrorResponse(400, CodeInvalidParameterValue,
+			fmt.Sprintf("Invalid AmazonOrderId: %v", c.orderID))
+	}
+
+	return NewResultResponse(req, struct {
+		NextToken     string        `xml:",omitempty"`
+		AmazonOrderId string
+		OrderItems    []interface{} `xml:"OrderItems>OrderItem"`
+	}{nextToken, c.orderID, page})
+}
+
+// listReports handle the GetReportList and GetReportListByNextToken actions.
+func (ms *Server) listReports(req *Request) *http.Response {
+	c, errResp := ms.cursor(req, "GetReportList", "MaxCount", 10)
+	if errResp != nil {
+		return errResp
+	}
+
+	ms.mu.Lock()
+	page, nextToken := ms.page(c, ms.dataset.Reports)
+	ms.mu.Unlock()
+
+	return NewResultResponse(req, struct {
+		NextToken  string        `xml:",omitempty"`
+		HasNext    bool
+		ReportInfo []interface{}
+	}{nextToken, nextToken != "", page})
+}
+
+// cursor return the cursor of the request, the first page for the list
+// 	action, or the page of the NextToken for the ByNextToken action.
+func (ms *Server) cursor(req *Request, action, sizeKey string, defaultSize int) (*cursor, *http.Response) {
+	if req.Action == action {
+		size := defaultSize
+		ms.mu.Lock()
+		if ms.dataset.PageSize > 0 {
+			size = ms.dataset.PageSize
+		}
+		ms.mu.Unlock()
+
+		if value := req.Params.Get(sizeKey); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 100 {
+				return nil, NewErrorResponse(400, CodeInvalidParameterValue,
+					fmt.Sprintf("%v must be between 1 and 100, got %v", sizeKey, value))
+			}
+			size = n
+		}
+		return &cursor{action: action, orderID: req.Params.Get("AmazonOrderId"), size: size}, nil
+	}
+
+	token := req.Params.Get("NextToken")
+	ms.mu.Lock()
+	c, ok := ms.cursors[token]
+	ms.mu.Unlock()
+
+	if !ok || c.action != action {
+		return nil, NewErrorResponse(400, CodeInvalidParameterValue,
+			fmt.Sprintf("Invalid NextToken: %v", token))
+	}
+	if time.Now().After(c.expires) {
+		return nil, NewErrorResponse(400, CodeInvalidParameterValue,
+			fmt.Sprintf("NextToken has expired: %v", token))
+	}
+	return c, nil
+}
+
+// page return the elements of the page at the cursor, and the NextToken if
+// 	more pages remained, must be called with the lock held.
+func (ms *Server) page(c *cursor, elements []interface{}) ([]interface{}, string) {
+	if c.offset >= len(elements) {
+		return []interface{}{}, ""
+	}
+
+	end := c.offset + c.size
+	if end >= len(elements) {
+		return elements[c.offset:], ""
+	}
+
+	token := newNextToken()
+	next := *c
+	next.offset = end
+	next.expires = time.Now().Add(ms.dataset.TokenExpiry)
+	ms.cursors[token] = &next
+	return elements[c.offset:end], token
+}
+
+// newNextToken generate a random opaque NextToken.
+func newNextToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.StdEncoding.EncodeToString(b)
+}
@@ -0,0 +1,203 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+	"github.com/svvu/gomws/mws/orders"
+	"github.com/svvu/gomws/mws/reports"
+)
+
+func TestServer_LoadDataset(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.LoadDataset(Dataset{
+		Orders: GenerateOrders(25),
+		OrderItems: map[string][]interface{}{
+			"000-0000000-0000001": {
+				orders.OrderItem{OrderItemId: "1", SellerSKU: "A"},
+				orders.OrderItem{OrderItemId: "2", SellerSKU: "B"},
+				orders.OrderItem{OrderItemId: "3", SellerSKU: "C"},
+			},
+		},
+		Reports: []interface{}{
+			reports.ReportInfo{ReportId: "1"},
+			reports.ReportInfo{ReportId: "2"},
+		},
+		PageSize: 2,
+	})
+	ctx := context.Background()
+
+	ordersClient, _ := orders.NewClient(mws.Config{
+		SellerId:  "SellerID",
+		AuthToken: "AuthToken",
+		Region:    "US",
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+	})
+	ordersClient.Host = server.Host()
+	ordersClient.Transport = NoVerifyTransport()
+
+	Convey("Split the orders by MaxResultsPerPage", t, func() {
+		pager := ordersClient.ListOrdersPager(mws.Parameters{"MaxResultsPerPage": 10})
+
+		sizes := []int{}
+		ids := []string{}
+		for pager.HasNextPage() {
+			page, err := pager.NextValues(ctx)
+			So(err, ShouldBeNil)
+			sizes = append(sizes, len(page))
+			for _, order := range page {
+				ids = append(ids, order.(*orders.Order).AmazonOrderId)
+			}
+		}
+
+		So(sizes, ShouldResemble, []int{10, 10, 5})
+		So(ids, ShouldHaveLength, 25)
+		So(ids[0], ShouldEqual, "000-0000000-0000001")
+		So(ids[24], ShouldEqual, "000-0000000-0000025")
+	})
+
+	Convey("Split the order items by the page size", t, func() {
+		pager := ordersClient.ListOrderItemsPager("000-0000000-0000001")
+
+		skus := []string{}
+		pages := 0
+		for pager.HasNextPage() {
+			page, err := pager.NextValues(ctx)
+			So(err, ShouldBeNil)
+			pages++
+			for _, item := range page {
+				skus = append(skus, item.(*orders.OrderItem).SellerSKU)
+			}
+		}
+
+		So(pages, ShouldEqual, 2)
+		So(skus, ShouldResemble, []string{"A", "B", "C"})
+	})
+
+	Convey("Split the reports by MaxCount", t, func() {
+		reportsClient := reportsClient(server)
+		pager := reportsClient.GetReportListPager(mws.Parameters{"MaxCount": 1})
+
+		ids := []string{}
+		for pager.HasNextPage() {
+			page, err := pager.NextValues(ctx)
+			So(err, ShouldBeNil)
+			So(page, ShouldHaveLength, 1)
+			ids = append(ids, page[0].(*reports.ReportInfo).ReportId)
+		}
+
+		So(ids, ShouldResemble, []string{"1", "2"})
+	})
+
+	Convey("Iterate the orders one by one", t, func() {
+		pager := ordersClient.ListOrdersPager(mws.Parameters{"MaxResultsPerPage": 10})
+
+		ids := []string{}
+		for pager.Next(ctx) {
+			ids = append(ids, pager.Value().(*orders.Order).AmazonOrderId)
+		}
+
+		So(pager.Err(), ShouldBeNil)
+		So(ids, ShouldHaveLength, 25)
+		So(ids[10], ShouldEqual, "000-0000000-0000011")
+	})
+
+	Convey("Iterate the order items one by one", t, func() {
+		pager := ordersClient.ListOrderItemsPager("000-0000000-0000001")
+
+		skus := []string{}
+		for pager.Next(ctx) {
+			skus = append(skus, pager.Value().(*orders.OrderItem).SellerSKU)
+		}
+
+		So(pager.Err(), ShouldBeNil)
+		So(skus, ShouldResemble, []string{"A", "B", "C"})
+	})
+
+	Convey("Iterate the reports one by one", t, func() {
+		pager := reportsClient(server).GetReportListPager(mws.Parameters{"MaxCount": 1})
+
+		ids := []string{}
+		for pager.Next(ctx) {
+			ids = append(ids, pager.Value().(*reports.ReportInfo).ReportId)
+		}
+
+		So(pager.Err(), ShouldBeNil)
+		So(ids, ShouldResemble, []string{"1", "2"})
+	})
+
+	Convey("Stop the iteration on error", t, func() {
+		pager := ordersClient.ListOrderItemsPager("unknown")
+
+		So(pager.Next(ctx), ShouldBeFalse)
+		So(pager.Err(), ShouldNotBeNil)
+	})
+
+	Convey("Pace the pages by the quota of the ByNextToken action", t, func() {
+		quota, ok := ordersClient.ListOrdersPager().Throttler.Quota("Orders", "ListOrdersByNextToken")
+		So(ok, ShouldBeTrue)
+		So(quota.MaxRequests, ShouldEqual, 6)
+		So(quota.RestoreRate, ShouldEqual, time.Minute)
+	})
+
+	Convey("Reject the unknown NextToken", t, func() {
+		resp, err := ordersClient.ListOrdersByNextToken("unknown")
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.StatusCode, ShouldEqual, 400)
+		So(errors.Is(resp.Error, mws.ErrInvalidParameterValue), ShouldBeTrue)
+	})
+
+	Convey("Reject the NextToken of other action", t, func() {
+		result, err := ordersClient.ListOrdersResult(ctx)
+		So(err, ShouldBeNil)
+		So(result.NextToken, ShouldNotBeEmpty)
+
+		resp, err := ordersClient.ListOrderItemsByNextToken(result.NextToken)
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(errors.Is(resp.Error, mws.ErrInvalidParameterValue), ShouldBeTrue)
+	})
+
+	Convey("Reject invalid MaxResultsPerPage", t, func() {
+		resp, err := ordersClient.ListOrders(mws.Parameters{"MaxResultsPerPage": 101})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(errors.Is(resp.Error, mws.ErrInvalidParameterValue), ShouldBeTrue)
+	})
+}
+
+func TestServer_LoadDataset_expiry(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.LoadDataset(Dataset{Orders: GenerateOrders(2), PageSize: 1, TokenExpiry: 50 * time.Millisecond})
+	client := testClient(server, "Orders", "2013-09-01")
+
+	Convey("Reject the expired NextToken", t, func() {
+		resp, err := client.SendRequest(mws.Parameters{"Action": "ListOrders"})
+		So(err, ShouldBeNil)
+		parser, err := resp.ResultParser()
+		resp.Close()
+		So(err, ShouldBeNil)
+		tokens := parser.FindByKey("NextToken")
+		So(tokens, ShouldHaveLength, 1)
+		token, _ := tokens[0].ToString()
+
+		time.Sleep(100 * time.Millisecond)
+		resp, err = client.SendRequest(mws.Parameters{"Action": "ListOrdersByNextToken", "NextToken": token})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.StatusCode, ShouldEqual, 400)
+		So(resp.Error.Error(), ShouldContainSubstring, "expired")
+	})
+}
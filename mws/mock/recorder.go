@@ -0,0 +1,266 @@
+package mock
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// RecorderMode is the mode of the Recorder.
+type RecorderMode int
+
+// Modes of the Recorder.
+const (
+	// ModeRecord send the requests and save the interactions.
+	ModeRecord RecorderMode = iota
+	// ModeReplay serve the saved interactions without sending the requests.
+	ModeReplay
+)
+
+// VolatileParams the parameters stripped from the recorded requests, they
+// 	change between sessions or are secrets.
+var VolatileParams = []string{
+	"Signature", "Timestamp", "AWSAccessKeyId", "MWSAuthToken", "SellerId", "Merchant",
+}
+
+// Recorder is a http.RoundTripper to record the MWS interactions to fixtures,
+// 	and replay them in tests.
+// Set it as the transport of the client:
+// 	client.Transport = mock.NewRecorder("./fixtures", mock.ModeRecord)
+// Each interaction is saved as a json file in the directory, named by the
+// 	action, a hash of the request and the number of times the same request
+// 	was sent, ex: ListOrders-1a2b3c4d-1.json.
+// The requests are matched by the path, action and the parameters without the
+// 	VolatileParams. The same request sent again get the next recorded
+// 	response, or the last one if no more recorded, ex: polling the status.
+type Recorder struct {
+	// Mode of the recorder.
+	Mode RecorderMode
+	// Directory of the fixtures.
+	Dir string
+	// Transport to send the requests in record mode.
+	// Default to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	mu    sync.Mutex
+	count map[string]int
+}
+
+// Interaction is a recorded request and response pair.
+type Interaction struct {
+	Request struct {
+		Method string
+		Path   string
+		Params url.Values
+		Body   string `json:",omitempty"`
+	}
+	Response struct {
+		StatusCode int
+		Header     http.Header
+		// Body is the body in UTF-8, or Base64Body for other encodings.
+		Body       string `json:",omitempty"`
+		Base64Body string `json:",omitempty"`
+	}
+}
+
+// NewRecorder create a recorder for the fixtures in the directory.
+func NewRecorder(dir string, mode RecorderMode) *Recorder {
+	return &Recorder{Mode: mode, Dir: dir, count: map[string]int{}}
+}
+
+// RoundTrip record or replay the request.
+func (rec *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := requestParams(req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{}
+	interaction.Request.Method = req.Method
+	interaction.Request.Path = req.URL.Path
+	interaction.Request.Params = params
+	if !isForm(req) {
+		interaction.Request.Body = string(body)
+	}
+
+	name := rec.fixtureName(interaction)
+	if rec.Mode == ModeReplay {
+		return rec.replay(req, name)
+	}
+	return rec.record(req, body, name, interaction)
+}
+
+// record send the request and save the interaction to the fixture.
+func (rec *Recorder) record(req *http.Request, body []byte, name string, interaction Interaction) (*http.Response, error) {
+	transport := rec.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction.Response.StatusCode = resp.StatusCode
+	interaction.Response.Header = resp.Header
+	if utf8.Valid(respBody) {
+		interaction.Response.Body = string(respBody)
+	} else {
+		interaction.Response.Base64Body = base64.StdEncoding.EncodeToString(respBody)
+	}
+
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(rec.Dir, 0755); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(rec.Dir, name+".json"), data, 0644); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// replay serve the response of the fixture.
+func (rec *Recorder) replay(req *http.Request, name string) (*http.Response, error) {
+	data, err := ioutil.ReadFile(filepath.Join(rec.Dir, name+".json"))
+	if os.IsNotExist(err) {
+		// Serve the last recorded response for the repeated request.
+		prefix := name[:strings.LastIndex(name, "-")]
+		for n := rec.countOf(prefix) - 1; n > 0 && os.IsNotExist(err); n-- {
+			data, err = ioutil.ReadFile(filepath.Join(rec.Dir, fmt.Sprintf("%v-%v.json", prefix, n)))
+		}
+	}
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("No recorded interaction for %v %v", req.Method, req.URL.Path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{}
+	if err := json.Unmarshal(data, &interaction); err != nil {
+		return nil, fmt.Errorf("Invalid fixture %v: %v", name, err)
+	}
+
+	body := []byte(interaction.Response.Body)
+	if interaction.Response.Base64Body != "" {
+		body, err = base64.StdEncoding.DecodeString(interaction.Response.Base64Body)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid fixture %v: %v", name, err)
+		}
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(interaction.Response.StatusCode),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        interaction.Response.Header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// fixtureName return the fixture name of the interaction, and count the
+// 	request sent.
+func (rec *Recorder) fixtureName(interaction Interaction) string {
+	key := interaction.Request.Method + "\n" +
+		interaction.Request.Path + "\n" +
+		interaction.Request.Params.Encode() + "\n" +
+		interaction.Request.Body
+	sum := sha1.Sum([]byte(key))
+	prefix := fmt.Sprintf("%v-%x", interaction.Request.Params.Get("Action"), sum[:4])
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if rec.count == nil {
+		rec.count = map[string]int{}
+	}
+	rec.count[prefix]++
+	return fmt.Sprintf("%v-%v", prefix, rec.count[prefix])
+}
+
+// countOf return the number of times the request sent.
+func (rec *Recorder) countOf(prefix string) int {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return rec.count[prefix]
+}
+
+// readRequestBody read the request body without modifying the request, the
+// 	copy from GetBody is read if available.
+// The body is closed as required by http.RoundTripper.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+
+	body := req.Body
+	if req.GetBody != nil {
+		copied, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer copied.Close()
+		body = copied
+	}
+	return ioutil.ReadAll(body)
+}
+
+// requestParams return the parameters in the query string and form body,
+// 	without the VolatileParams.
+func requestParams(req *http.Request, body []byte) (url.Values, error) {
+	params := url.Values{}
+	for k, v := range req.URL.Query() {
+		params[k] = v
+	}
+	if isForm(req) {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range form {
+			params[k] = append(params[k], v...)
+		}
+	}
+
+	for _, key := range VolatileParams {
+		params.Del(key)
+	}
+	return params, nil
+}
+
+// isForm check whether or not the request body is the form parameters.
+func isForm(req *http.Request) bool {
+	return strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded")
+}
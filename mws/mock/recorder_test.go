@@ -0,0 +1,91 @@
+package mock
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+)
+
+func TestRecorder(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "recorder")
+	defer os.RemoveAll(dir)
+
+	server := NewServer()
+	server.SimulateReports(Lifecycle{Polls: 1})
+	reportsClient := reportsClient(server)
+	recorder := NewRecorder(dir, ModeRecord)
+	recorder.Transport = NoVerifyTransport()
+	reportsClient.Transport = recorder
+
+	body := func(resp *mws.Response, err error) string {
+		So(err, ShouldBeNil)
+		defer resp.Close()
+		b, err := ioutil.ReadAll(resp.Body)
+		So(err, ShouldBeNil)
+		return string(b)
+	}
+
+	var requested, inProgress, done string
+	Convey("Record the interactions without volatile parameters", t, func() {
+		requested = body(reportsClient.RequestReport("_GET_MERCHANT_LISTINGS_DATA_"))
+		So(requested, ShouldContainSubstring, StatusSubmitted)
+
+		inProgress = body(reportsClient.GetReportRequestList())
+		done = body(reportsClient.GetReportRequestList())
+		So(inProgress, ShouldContainSubstring, StatusInProgress)
+		So(done, ShouldContainSubstring, StatusDoneNoData)
+
+		files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+		So(files, ShouldHaveLength, 3)
+		for _, file := range files {
+			data, _ := ioutil.ReadFile(file)
+			So(string(data), ShouldContainSubstring, `"Action"`)
+			for _, key := range VolatileParams {
+				So(string(data), ShouldNotContainSubstring, `"`+key+`"`)
+			}
+			So(string(data), ShouldNotContainSubstring, "SellerID")
+		}
+		list, _ := filepath.Glob(filepath.Join(dir, "GetReportRequestList-*.json"))
+		So(list, ShouldHaveLength, 2)
+	})
+	server.Close()
+
+	Convey("Replay the interactions without the server", t, func() {
+		replayClient := reportsClient
+		replayClient.Transport = NewRecorder(dir, ModeReplay)
+
+		So(body(replayClient.RequestReport("_GET_MERCHANT_LISTINGS_DATA_")), ShouldEqual, requested)
+		So(body(replayClient.GetReportRequestList()), ShouldEqual, inProgress)
+		So(body(replayClient.GetReportRequestList()), ShouldEqual, done)
+
+		Convey("Serve the last response for the repeated request", func() {
+			So(body(replayClient.GetReportRequestList()), ShouldEqual, done)
+		})
+
+		Convey("Fail the request not recorded", func() {
+			_, err := replayClient.RequestReport("_GET_FLAT_FILE_OPEN_LISTINGS_DATA_")
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "No recorded interaction")
+		})
+	})
+
+	Convey("Not modify the request", t, func() {
+		req, _ := http.NewRequest("POST", "https://mws.amazonservices.com/Reports/2009-01-01",
+			strings.NewReader("Action=GetReportList"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		body := req.Body
+
+		NewRecorder(dir, ModeReplay).RoundTrip(req)
+
+		So(req.Body == body, ShouldBeTrue)
+		copied, _ := req.GetBody()
+		data, _ := ioutil.ReadAll(copied)
+		So(string(data), ShouldEqual, "Action=GetReportList")
+	})
+}
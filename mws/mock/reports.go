@@ -0,0 +1,230 @@
+package mock
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+)
+
+// reportRequestInfo the ReportRequestInfo element.
+type reportRequestInfo struct {
+	ReportRequestId        string
+	ReportType             string
+	StartDate              string
+	EndDate                string
+	Scheduled              bool
+	SubmittedDate          string
+	ReportProcessingStatus string
+	GeneratedReportId      string `xml:",omitempty"`
+	StartedProcessingDate  string `xml:",omitempty"`
+	CompletedDate          string `xml:",omitempty"`
+}
+
+// reportInfo the ReportInfo element.
+type reportInfo struct {
+	ReportId        string
+	ReportType      string
+	ReportRequestId string
+	AvailableDate   string
+	Acknowledged    bool
+}
+
+// reportRequest is a report request simulated by the server.
+type reportRequest struct {
+	id         string
+	reportType string
+	startDate  string
+	endDate    string
+	progress   *progress
+
+	// Set once the request is done with the registered report body.
+	reportID string
+	body     []byte
+}
+
+// info return the ReportRequestInfo of the report request.
+func (rr *reportRequest) info() reportRequestInfo {
+	info := reportRequestInfo{
+		ReportRequestId:        rr.id,
+		ReportType:             rr.reportType,
+		StartDate:              rr.startDate,
+		EndDate:                rr.endDate,
+		SubmittedDate:          formatTime(rr.progress.submitted),
+		ReportProcessingStatus: rr.progress.status,
+		GeneratedReportId:      rr.reportID,
+		StartedProcessingDate:  formatTime(rr.progress.started),
+		CompletedDate:          formatTime(rr.progress.completed),
+	}
+	if info.ReportProcessingStatus == StatusDone && rr.reportID == "" {
+		info.ReportProcessingStatus = StatusDoneNoData
+	}
+	return info
+}
+
+// SimulateReports simulate the report requests with states, the report
+// 	requests move through the statuses by the lifecycle.
+// The actions handled:
+// 	RequestReport - create a report request in _SUBMITTED_ status.
+// 	GetReportRequestList - count as a poll of the listed report requests.
+// 	CancelReportRequests - cancel the listed report requests in _SUBMITTED_ status.
+// 	GetReportList - list the reports generated.
+// 	GetReport - return the report body with the Content-MD5 header.
+// The report request is done with the body registered by RegisterReport for
+// 	the report type, or _DONE_NO_DATA_ if no body registered.
+func (ms *Server) SimulateReports(lifecycle Lifecycle) {
+	ms.HandleAction("Reports", "RequestReport", func(req *Request) *http.Response {
+		return ms.requestReport(req, lifecycle)
+	})
+	ms.HandleAction("Reports", "GetReportRequestList", ms.getReportRequestList)
+	ms.HandleAction("Reports", "CancelReportRequests", ms.cancelReportRequests)
+	ms.HandleAction("Reports", "GetReportList", ms.getReportList)
+	ms.HandleAction("Reports", "GetReport", ms.getReport)
+}
+
+// RegisterReport register the report body for the report type. The report
+// 	requests of the type done after that return the body.
+func (ms *Server) RegisterReport(reportType string, body []byte) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.reportBodies[reportType] = body
+}
+
+// requestReport handle the RequestReport action.
+func (ms *Server) requestReport(req *Request, lifecycle Lifecycle) *http.Response {
+	reportType := req.Params.Get("ReportType")
+	if reportType == "" {
+		return NewErrorResponse(400, CodeInvalidParameterValue, "ReportType is required")
+	}
+
+	ms.mu.Lock()
+	rr := &reportRequest{
+		id:         ms.nextID(),
+		reportType: reportType,
+		progress:   newProgress(lifecycle),
+	}
+	rr.startDate = req.Params.Get("StartDate")
+	if rr.startDate == "" {
+		rr.startDate = formatTime(rr.progress.submitted)
+	}
+	rr.endDate = req.Params.Get("EndDate")
+	if rr.endDate == "" {
+		rr.endDate = formatTime(rr.progress.submitted)
+	}
+	ms.reportRequests = append(ms.reportRequests, rr)
+	info := rr.info()
+	ms.mu.Unlock()
+
+	return NewResultResponse(req, struct {
+		ReportRequestInfo reportRequestInfo
+	}{info})
+}
+
+// getReportRequestList handle the GetReportRequestList action.
+func (ms *Server) getReportRequestList(req *Request) *http.Response {
+	ids := listParam(req.Params, "ReportRequestIdList.Id")
+	types := listParam(req.Params, "ReportTypeList.Type")
+	statuses := listParam(req.Params, "ReportProcessingStatusList.Status")
+
+	ms.mu.Lock()
+	infos := []reportRequestInfo{}
+	for _, rr := range ms.reportRequests {
+		if !matchList(ids, rr.id) || !matchList(types, rr.reportType) {
+			continue
+		}
+		if rr.progress.poll() {
+			if body, ok := ms.reportBodies[rr.reportType]; ok {
+				rr.reportID = ms.nextID()
+				rr.body = body
+			}
+		}
+		info := rr.info()
+		if matchList(statuses, info.ReportProcessingStatus) {
+			infos = append(infos, info)
+		}
+	}
+	ms.mu.Unlock()
+
+	return NewResultResponse(req, struct {
+		HasNext           bool
+		ReportRequestInfo []reportRequestInfo
+	}{false, infos})
+}
+
+// cancelReportRequests handle the CancelReportRequests action.
+func (ms *Server) cancelReportRequests(req *Request) *http.Response {
+	ids := listParam(req.Params, "ReportRequestIdList.Id")
+	types := listParam(req.Params, "ReportTypeList.Type")
+
+	ms.mu.Lock()
+	infos := []reportRequestInfo{}
+	for _, rr := range ms.reportRequests {
+		if matchList(ids, rr.id) && matchList(types, rr.reportType) && rr.progress.cancel() {
+			infos = append(infos, rr.info())
+		}
+	}
+	ms.mu.Unlock()
+
+	return NewResultResponse(req, struct {
+		Count             int
+		ReportRequestInfo []reportRequestInfo
+	}{len(infos), infos})
+}
+
+// getReportList handle the GetReportList action.
+func (ms *Server) getReportList(req *Request) *http.Response {
+	ids := listParam(req.Params, "ReportRequestIdList.Id")
+	types := listParam(req.Params, "ReportTypeList.Type")
+
+	ms.mu.Lock()
+	infos := []reportInfo{}
+	for _, rr := range ms.reportRequests {
+		if rr.reportID == "" || !matchList(ids, rr.id) || !matchList(types, rr.reportType) {
+			continue
+		}
+		infos = append(infos, reportInfo{
+			ReportId:        rr.reportID,
+			ReportType:      rr.reportType,
+			ReportRequestId: rr.id,
+			AvailableDate:   formatTime(rr.progress.completed),
+		})
+	}
+	ms.mu.Unlock()
+
+	return NewResultResponse(req, struct {
+		HasNext    bool
+		ReportInfo []reportInfo
+	}{false, infos})
+}
+
+// getReport handle the GetReport action.
+func (ms *Server) getReport(req *Request) *http.Response {
+	reportID := req.Params.Get("ReportId")
+
+	ms.mu.Lock()
+	var body []byte
+	found := false
+	for _, rr := range ms.reportRequests {
+		if rr.reportID != "" && rr.reportID == reportID {
+			body, found = rr.body, true
+			break
+		}
+	}
+	ms.mu.Unlock()
+
+	if !found {
+		return NewErrorResponse(400, CodeInvalidParameterValue,
+			fmt.Sprintf("Report %v not found", reportID))
+	}
+
+	resp := NewResponse(200, string(body))
+	resp.Header.Set("Content-Type", "text/plain;charset=UTF-8")
+	resp.Header.Set("Content-MD5", contentMD5(body))
+	return resp
+}
+
+// contentMD5 return the base64 encoded md5 of the content.
+func contentMD5(content []byte) string {
+	sum := md5.Sum(content)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,129 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+	"github.com/svvu/gomws/mws/reports"
+)
+
+func reportsClient(server *Server) *reports.Reports {
+	client, _ := reports.NewClient(mws.Config{
+		SellerId:  "SellerID",
+		AuthToken: "AuthToken",
+		Region:    "US",
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+	})
+	client.Host = server.Host()
+	client.Transport = NoVerifyTransport()
+	return client
+}
+
+func TestServer_SimulateReports(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SimulateReports(Lifecycle{Polls: 1})
+	server.RegisterReport("_GET_MERCHANT_LISTINGS_DATA_", []byte("sku\tprice\nABC\t1.00\n"))
+	client := reportsClient(server)
+	ctx := context.Background()
+
+	Convey("Fetch the registered report through the statuses", t, func() {
+		statuses := []string{}
+		report, err := client.Fetch(ctx, "_GET_MERCHANT_LISTINGS_DATA_", reports.FetchOptions{
+			PollInterval: time.Millisecond,
+			Progress: func(p reports.FetchProgress) {
+				statuses = append(statuses, p.Info.ReportProcessingStatus)
+			},
+		})
+		So(err, ShouldBeNil)
+		defer report.Close()
+
+		So(statuses, ShouldResemble, []string{StatusSubmitted, StatusInProgress, StatusDone})
+		So(report.Info.GeneratedReportId, ShouldNotBeEmpty)
+		So(report.Header.Get("Content-MD5"), ShouldEqual, contentMD5([]byte("sku\tprice\nABC\t1.00\n")))
+		body, err := ioutil.ReadAll(report)
+		So(err, ShouldBeNil)
+		So(string(body), ShouldEqual, "sku\tprice\nABC\t1.00\n")
+
+		resp, err := client.GetReportList(mws.Parameters{
+			"ReportRequestIdList": []string{report.Info.ReportRequestId},
+		})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+		info := reports.ReportInfo{}
+		So(resp.DecodeResult("ReportInfo", &info), ShouldBeNil)
+		So(info.ReportId, ShouldEqual, report.Info.GeneratedReportId)
+	})
+
+	Convey("Done with no data if no report registered", t, func() {
+		_, err := client.Fetch(ctx, "_GET_FLAT_FILE_OPEN_LISTINGS_DATA_", reports.FetchOptions{
+			PollInterval: time.Millisecond,
+		})
+		So(errors.Is(err, reports.ErrReportNoData), ShouldBeTrue)
+	})
+
+	Convey("Cancel the submitted report request", t, func() {
+		resp, err := client.RequestReport("_GET_MERCHANT_LISTINGS_DATA_")
+		So(err, ShouldBeNil)
+		info := reports.ReportRequestInfo{}
+		So(resp.DecodeResult("ReportRequestInfo", &info), ShouldBeNil)
+		resp.Close()
+
+		resp, err = client.CancelReportRequests(mws.Parameters{
+			"ReportRequestIdList": []string{info.ReportRequestId},
+		})
+		So(err, ShouldBeNil)
+		So(resp.DecodeResult("ReportRequestInfo", &info), ShouldBeNil)
+		resp.Close()
+		So(info.ReportProcessingStatus, ShouldEqual, StatusCancelled)
+
+		resp, err = client.GetReportRequestList(mws.Parameters{
+			"ReportRequestIdList": []string{info.ReportRequestId},
+		})
+		So(err, ShouldBeNil)
+		So(resp.DecodeResult("ReportRequestInfo", &info), ShouldBeNil)
+		resp.Close()
+		So(info.ReportProcessingStatus, ShouldEqual, StatusCancelled)
+	})
+
+	Convey("Reject unknown report", t, func() {
+		resp, err := client.GetReport("1")
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(errors.Is(resp.Error, mws.ErrInvalidParameterValue), ShouldBeTrue)
+	})
+}
+
+func TestServer_SimulateReports_duration(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SimulateReports(Lifecycle{Duration: 100 * time.Millisecond})
+	client := reportsClient(server)
+
+	status := func(id string) string {
+		resp, _ := client.GetReportRequestList(mws.Parameters{"ReportRequestIdList": []string{id}})
+		defer resp.Close()
+		info := reports.ReportRequestInfo{}
+		resp.DecodeResult("ReportRequestInfo", &info)
+		return info.ReportProcessingStatus
+	}
+
+	Convey("Move the status by time", t, func() {
+		resp, err := client.RequestReport("_GET_MERCHANT_LISTINGS_DATA_")
+		So(err, ShouldBeNil)
+		info := reports.ReportRequestInfo{}
+		So(resp.DecodeResult("ReportRequestInfo", &info), ShouldBeNil)
+		resp.Close()
+
+		So(status(info.ReportRequestId), ShouldEqual, StatusSubmitted)
+		time.Sleep(250 * time.Millisecond)
+		So(status(info.ReportRequestId), ShouldEqual, StatusDoneNoData)
+	})
+}
@@ -5,15 +5,55 @@ import (
 	"crypto/tls"
 	"fmt"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Server a mock server to capture request to API.
+//
+// Without a response set by SetResponse or SetResponseHandler, the server
+// 	simulate the MWS:
+// 	- Route the request by the API name in the path and the Action.
+// 	- Reject the request without SellerId or with stale Timestamp.
+// 	- Verify the signature if the credential is set by SetCredential.
+// 	- Serve the action handlers, or the exampleResponses of the API.
+// The report requests and feed submissions can be simulated with states by
+// 	SimulateReports and SimulateFeeds, the throttling and faults by
+// 	SimulateThrottling and SimulateFaults. The list actions can serve a
+// 	dataset page by page by LoadDataset.
 type Server struct {
 	*httptest.Server
 	responseHandler func(r *http.Request) *http.Response
+
+	// Max difference between the Timestamp of the request and the server time,
+	// 	zero to skip the check. Default to 15 minutes.
+	TimestampTolerance time.Duration
+
+	mu          sync.Mutex
+	credentials map[string]string
+	handlers    map[string]ActionHandler
+	exampleDirs map[string]string
+	exampleRoot string
+
+	sequence        int64
+	reportRequests  []*reportRequest
+	reportBodies    map[string][]byte
+	feedSubmissions []*feedSubmission
+	feedResults     map[string][]byte
+
+	throttling bool
+	quotas     map[string]RequestQuota
+	buckets    map[string]*bucket
+	faults     Faults
+	random     *rand.Rand
+
+	dataset Dataset
+	cursors map[string]*cursor
 }
 
 // SetResponse set the response for the incoming requests.
@@ -32,49 +72,85 @@ func (ms *Server) SetResponse(resp *http.Response) {
 }
 
 // SetResponseHandler set a handler to update reponse base on request info.
+// Set the handler to nil to go back to the MWS simulation.
 func (ms *Server) SetResponseHandler(handler func(r *http.Request) *http.Response) {
 	ms.responseHandler = handler
 }
 
 // Host return the url without the method (URN).
 func (ms *Server) Host() string {
-	return strings.Replace(ms.Server.URL, "https://", "", -1)
+	return strings.TrimPrefix(strings.TrimPrefix(ms.Server.URL, "https://"), "http://")
 }
 
-// NewServer create and start a new mock Server.
+// NewServer create and start a new mock Server over https.
+// The clients need the NoVerifyTransport to accept the certificate.
 func NewServer() *Server {
-	server := &Server{}
+	return newServer(httptest.NewTLSServer)
+}
 
-	handler := func(w http.ResponseWriter, r *http.Request) {
-		var resp *http.Response
+// NewHTTPServer create and start a new mock Server over plain http, the
+// 	clients can send the requests to its URL without a custom transport.
+func NewHTTPServer() *Server {
+	return newServer(httptest.NewServer)
+}
 
-		if server.responseHandler == nil {
-			resp = &http.Response{}
-		} else {
-			resp = server.responseHandler(r)
-		}
+// newServer create the mock Server and start it by the start function.
+func newServer(start func(http.Handler) *httptest.Server) *Server {
+	server := &Server{
+		TimestampTolerance: 15 * time.Minute,
+		credentials:        map[string]string{},
+		handlers:           map[string]ActionHandler{},
+		exampleDirs:        map[string]string{},
+		reportBodies:       map[string][]byte{},
+		feedResults:        map[string][]byte{},
+		quotas:             map[string]RequestQuota{},
+		buckets:            map[string]*bucket{},
+		random:             rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
 
-		for k, v := range resp.Header {
-			w.Header()[k] = v
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if server.responseHandler != nil {
+			writeResponse(w, server.responseHandler(r), false)
+			return
 		}
 
-		body, err := ioutil.ReadAll(resp.Body)
-		defer resp.Body.Close()
-
-		if err != nil {
-			w.WriteHeader(500)
-			fmt.Fprint(w, err.Error())
-		} else {
-			w.WriteHeader(resp.StatusCode)
-			fmt.Fprint(w, string(body))
-		}
+		resp := server.serveMWS(r)
+		server.injectLatency(r.Context())
+		writeResponse(w, resp, resp.StatusCode == 200 && server.injectTruncation())
 	}
 
-	server.Server = httptest.NewTLSServer(http.HandlerFunc(handler))
+	server.Server = start(http.HandlerFunc(handler))
 
 	return server
 }
 
+// writeResponse write the response to the response writer.
+// If truncate, only half of the body is written with the full Content-Length.
+func writeResponse(w http.ResponseWriter, resp *http.Response, truncate bool) {
+	if resp.Body == nil {
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer(nil))
+	}
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	defer resp.Body.Close()
+
+	if err != nil {
+		w.WriteHeader(500)
+		fmt.Fprint(w, err.Error())
+	} else if truncate {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(resp.StatusCode)
+		w.Write(body[:len(body)/2])
+	} else {
+		w.WriteHeader(resp.StatusCode)
+		w.Write(body)
+	}
+}
+
 // NoVerifyTransport return transport that skip the certificate verification.
 func NoVerifyTransport() *http.Transport {
 	return &http.Transport{
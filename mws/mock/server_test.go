@@ -0,0 +1,195 @@
+package mock
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+)
+
+func testClient(server *Server, name, version string) *mws.Client {
+	client, _ := mws.NewClient(mws.Config{
+		SellerId:  "SellerID",
+		AuthToken: "AuthToken",
+		Region:    "US",
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+	}, version, name)
+	client.Host = server.Host()
+	client.Transport = NoVerifyTransport()
+	return client
+}
+
+func TestServer_MWS(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SetCredential("AccessKey", "SecretKey")
+	client := testClient(server, "Orders", "2013-09-01")
+
+	Convey("Serve the example response of the action", t, func() {
+		resp, err := client.SendRequest(mws.Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.Error, ShouldBeNil)
+		So(resp.RequestID(), ShouldNotBeEmpty)
+		So(resp.Timestamp().IsZero(), ShouldBeFalse)
+		body, _ := ioutil.ReadAll(resp.Body)
+		So(string(body), ShouldContainSubstring, "<GetOrderResponse")
+	})
+
+	Convey("Serve the common example response", t, func() {
+		resp, err := client.SendRequest(mws.Parameters{"Action": "GetServiceStatus"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.Error, ShouldBeNil)
+	})
+
+	Convey("Route by the name and version at the end of the path", t, func() {
+		client := testClient(server, "Orders", "2013-09-01")
+		client.BasePath = "/proxy/mws"
+
+		resp, err := client.SendRequest(mws.Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.Error, ShouldBeNil)
+		body, _ := ioutil.ReadAll(resp.Body)
+		So(string(body), ShouldContainSubstring, "<GetOrderResponse")
+	})
+
+	Convey("Serve the example response under the example root", t, func() {
+		server := NewServer()
+		defer server.Close()
+		client := testClient(server, "Orders", "2013-09-01")
+
+		server.SetExampleRoot("..")
+		resp, err := client.SendRequest(mws.Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		So(resp.Error, ShouldBeNil)
+		resp.Close()
+
+		server.SetExampleRoot("./missing")
+		resp, err = client.SendRequest(mws.Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		So(resp.StatusCode, ShouldEqual, 404)
+		resp.Close()
+	})
+
+	Convey("Serve the action handler", t, func() {
+		server.HandleAction("Orders", "ListOrders", func(req *Request) *http.Response {
+			return NewResponse(200, "<ListOrdersResponse>"+req.Params.Get("CreatedAfter")+"</ListOrdersResponse>")
+		})
+
+		resp, err := client.SendRequest(mws.Parameters{"Action": "ListOrders", "CreatedAfter": "2017-01-01"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		So(string(body), ShouldEqual, "<ListOrdersResponse>2017-01-01</ListOrdersResponse>")
+	})
+
+	Convey("Verify the signature of body-carrying request", t, func() {
+		var feed []byte
+		server.HandleAction("Feeds", "SubmitFeed", func(req *Request) *http.Response {
+			feed = req.Body
+			return NewResponse(200, "<SubmitFeedResponse/>")
+		})
+		feedsClient := testClient(server, "Feeds", "2009-01-01")
+
+		resp, err := feedsClient.SendBodyRequest(mws.Parameters{"Action": "SubmitFeed"}, mws.Body{Content: []byte("feed")})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.Error, ShouldBeNil)
+		So(string(feed), ShouldEqual, "feed")
+	})
+
+	Convey("Reject unknown action", t, func() {
+		resp, err := client.SendRequest(mws.Parameters{"Action": "Unknown"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.StatusCode, ShouldEqual, 404)
+		So(errors.Is(resp.Error, mws.ErrInvalidAddress), ShouldBeTrue)
+	})
+
+	Convey("Reject wrong signature", t, func() {
+		badClient, _ := mws.NewClient(mws.Config{
+			SellerId:  "SellerID",
+			AccessKey: "AccessKey",
+			SecretKey: "WrongKey",
+		}, "2013-09-01", "Orders")
+		badClient.Host = server.Host()
+		badClient.Transport = NoVerifyTransport()
+
+		resp, err := badClient.SendRequest(mws.Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.StatusCode, ShouldEqual, 403)
+		So(errors.Is(resp.Error, mws.ErrSignatureDoesNotMatch), ShouldBeTrue)
+	})
+
+	Convey("Reject unknown access key", t, func() {
+		badClient, _ := mws.NewClient(mws.Config{
+			SellerId:  "SellerID",
+			AccessKey: "OtherKey",
+			SecretKey: "SecretKey",
+		}, "2013-09-01", "Orders")
+		badClient.Host = server.Host()
+		badClient.Transport = NoVerifyTransport()
+
+		resp, err := badClient.SendRequest(mws.Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(errors.Is(resp.Error, mws.ErrInvalidAccessKeyId), ShouldBeTrue)
+	})
+}
+
+func TestServer_validate(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	request := func(params string) *http.Response {
+		req, _ := http.NewRequest("POST", server.URL+"/Orders/2013-09-01", nil)
+		req.URL.RawQuery = params
+		resp, _ := server.Client().Do(req)
+		return resp
+	}
+
+	Convey("Reject request without SellerId", t, func() {
+		resp := request("Action=GetOrder")
+		defer resp.Body.Close()
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		So(resp.StatusCode, ShouldEqual, 400)
+		So(string(body), ShouldContainSubstring, "<Code>InvalidParameterValue</Code>")
+		So(string(body), ShouldContainSubstring, "SellerId")
+	})
+
+	Convey("Reject request with stale timestamp", t, func() {
+		resp := request("Action=GetOrder&SellerId=SellerID&Timestamp=2015-10-20T22%3A46%3A07Z")
+		defer resp.Body.Close()
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		So(resp.StatusCode, ShouldEqual, 400)
+		So(string(body), ShouldContainSubstring, "Request has expired")
+	})
+
+	Convey("Skip the timestamp check when tolerance is zero", t, func() {
+		server.TimestampTolerance = 0
+		defer func() { server.TimestampTolerance = 15 * time.Minute }()
+
+		resp := request("Action=GetOrder&SellerId=SellerID&Timestamp=2015-10-20T22%3A46%3A07Z")
+		defer resp.Body.Close()
+
+		So(resp.StatusCode, ShouldEqual, 200)
+	})
+}
@@ -0,0 +1,118 @@
+package mock
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/svvu/gomws/mws/throttling"
+)
+
+// RequestQuota is the throttling limit of an action, same type as
+// 	mws.RequestQuota.
+type RequestQuota = throttling.Quota
+
+// DefaultRequestQuotas the documented quota for the actions, key by
+// 	"Name/Action", ex: "Orders/ListOrders".
+// Same table as mws.DefaultRequestQuotas.
+var DefaultRequestQuotas = throttling.DefaultQuotas
+
+// bucket is a leaky bucket for one action of one seller.
+// The level is the number of requests in the bucket, it leak one request per
+// 	restore rate. The requests are also counted per hour.
+type bucket struct {
+	level float64
+	last  time.Time
+
+	hour     time.Time
+	requests int
+}
+
+// SimulateThrottling throttle the requests by the quotas of the actions.
+// Each seller has a leaky bucket per action, the request is rejected with
+// 	503 RequestThrottled when the bucket is full, or 503 QuotaExceeded when
+// 	the hourly quota is used up.
+// The responses of the actions with quota have the x-mws-quota-max,
+// 	x-mws-quota-remaining and x-mws-quota-resetsOn headers.
+// The DefaultRequestQuotas is used unless the quota is set by SetQuota.
+func (ms *Server) SimulateThrottling() {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.throttling = true
+}
+
+// SetQuota set the quota for the action, ex: "Orders", "ListOrders".
+func (ms *Server) SetQuota(name, action string, quota RequestQuota) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.quotas[throttling.Key(name, action)] = quota
+}
+
+// throttle put the request in the bucket of the action.
+// Return the quota headers, and the error response if the request is throttled.
+func (ms *Server) throttle(req *Request) (http.Header, *http.Response) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if !ms.throttling {
+		return nil, nil
+	}
+
+	key := throttling.Key(req.Name, req.Action)
+	quota, ok := ms.quotas[key]
+	if !ok {
+		quota, ok = DefaultRequestQuotas[key]
+	}
+	if !ok || quota.MaxRequests <= 0 || quota.RestoreRate <= 0 {
+		return nil, nil
+	}
+
+	seller := req.Params.Get("SellerId")
+	if seller == "" {
+		seller = req.Params.Get("Merchant")
+	}
+	b, ok := ms.buckets[key+"/"+seller]
+	if !ok {
+		b = &bucket{}
+		ms.buckets[key+"/"+seller] = b
+	}
+
+	now := time.Now()
+	b.leak(quota, now)
+
+	var errResp *http.Response
+	switch {
+	case b.requests >= quota.Hourly():
+		errResp = NewErrorResponse(503, CodeQuotaExceeded,
+			fmt.Sprintf("You exceeded your quota of %v requests per 1 hour for operation %v", quota.Hourly(), key))
+	case b.level+1 > float64(quota.MaxRequests):
+		errResp = NewErrorResponse(503, CodeRequestThrottled, "Request is throttled")
+	default:
+		b.level++
+		b.requests++
+	}
+
+	header := http.Header{}
+	header.Set("x-mws-quota-max", strconv.FormatFloat(float64(quota.Hourly()), 'f', 1, 64))
+	header.Set("x-mws-quota-remaining", strconv.FormatFloat(float64(quota.Hourly()-b.requests), 'f', 1, 64))
+	header.Set("x-mws-quota-resetsOn", formatTime(b.hour.Add(time.Hour)))
+	return header, errResp
+}
+
+// leak remove the requests restored since last leak, and reset the hourly
+// 	count when the hour passed.
+func (b *bucket) leak(quota RequestQuota, now time.Time) {
+	if !b.last.IsZero() {
+		b.level -= float64(now.Sub(b.last)) / float64(quota.RestoreRate)
+		if b.level < 0 {
+			b.level = 0
+		}
+	}
+	b.last = now
+
+	if hour := now.Truncate(time.Hour); !hour.Equal(b.hour) {
+		b.hour = hour
+		b.requests = 0
+	}
+}
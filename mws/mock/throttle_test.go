@@ -0,0 +1,92 @@
+package mock
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+)
+
+func TestServer_SimulateThrottling(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SimulateThrottling()
+	server.SetQuota("Orders", "GetOrder", RequestQuota{MaxRequests: 2, RestoreRate: 100 * time.Millisecond, HourlyRequests: 4})
+	client := testClient(server, "Orders", "2013-09-01")
+
+	send := func() *mws.Response {
+		resp, err := client.SendRequest(mws.Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		resp.Close()
+		return resp
+	}
+
+	Convey("Throttle the requests exceed the quota", t, func() {
+		resp := send()
+		So(resp.Error, ShouldBeNil)
+		quota, ok := resp.Quota()
+		So(ok, ShouldBeTrue)
+		So(quota.Max, ShouldEqual, 4)
+		So(quota.Remaining, ShouldEqual, 3)
+		So(quota.ResetsOn, ShouldEqual, time.Now().UTC().Truncate(time.Hour).Add(time.Hour))
+
+		So(send().Error, ShouldBeNil)
+
+		resp = send()
+		So(resp.StatusCode, ShouldEqual, 503)
+		So(errors.Is(resp.Error, mws.ErrThrottled), ShouldBeTrue)
+		quota, _ = resp.Quota()
+		So(quota.Remaining, ShouldEqual, 2)
+
+		time.Sleep(250 * time.Millisecond)
+		So(send().Error, ShouldBeNil)
+		So(send().Error, ShouldBeNil)
+
+		time.Sleep(250 * time.Millisecond)
+		resp = send()
+		So(errors.Is(resp.Error, mws.ErrQuotaExceeded), ShouldBeTrue)
+		quota, _ = resp.Quota()
+		So(quota.Remaining, ShouldEqual, 0)
+	})
+
+	Convey("Retry the throttled request", t, func() {
+		retryClient := testClient(server, "Orders", "2013-09-01")
+		retryClient.Retry = &mws.RetryPolicy{
+			MaxAttempts:    3,
+			BaseDelay:      150 * time.Millisecond,
+			MaxDelay:       time.Second,
+			RetryableCodes: []string{"RequestThrottled"},
+		}
+		server.SetQuota("Orders", "ListOrders", RequestQuota{MaxRequests: 1, RestoreRate: 100 * time.Millisecond})
+
+		resp, err := retryClient.SendRequest(mws.Parameters{"Action": "ListOrders"})
+		So(err, ShouldBeNil)
+		resp.Close()
+		resp, err = retryClient.SendRequest(mws.Parameters{"Action": "ListOrders"})
+		So(err, ShouldBeNil)
+		resp.Close()
+
+		So(resp.Error, ShouldBeNil)
+		So(resp.Attempts, ShouldHaveLength, 2)
+	})
+
+	Convey("Not throttle the actions without quota", t, func() {
+		unknown, err := client.SendRequest(mws.Parameters{"Action": "Unknown"})
+		So(err, ShouldBeNil)
+		unknown.Close()
+
+		_, ok := unknown.Quota()
+		So(ok, ShouldBeFalse)
+	})
+}
+
+func TestDefaultRequestQuotas(t *testing.T) {
+	Convey("Share the quota table with mws", t, func() {
+		mws.DefaultRequestQuotas["Test/Action"] = mws.RequestQuota{MaxRequests: 1, RestoreRate: time.Second}
+		defer delete(mws.DefaultRequestQuotas, "Test/Action")
+
+		So(DefaultRequestQuotas["Test/Action"].MaxRequests, ShouldEqual, 1)
+	})
+}
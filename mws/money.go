@@ -0,0 +1,21 @@
+package mws
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Money is a currency type and amount, ex: the order total of Orders API and
+// 	the listing price of Products API.
+// The amount is kept as the decimal string returned by MWS, float64 can't
+// 	represent all the decimal amounts exactly, ex: 0.1.
+type Money struct {
+	CurrencyCode string `xml:"CurrencyCode"`
+	Amount       string `xml:"Amount"`
+}
+
+// Float return the amount as float64, ex: for display or estimation.
+// Use the Amount string for the exact value.
+func (m Money) Float() (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(m.Amount), 64)
+}
@@ -0,0 +1,29 @@
+package mws
+
+import (
+	"encoding/xml"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMoney(t *testing.T) {
+	Convey("Keep the amount as the decimal string", t, func() {
+		money := Money{}
+		err := xml.Unmarshal([]byte(
+			"<OrderTotal><CurrencyCode>USD</CurrencyCode><Amount>0.10</Amount></OrderTotal>",
+		), &money)
+
+		So(err, ShouldBeNil)
+		So(money, ShouldResemble, Money{CurrencyCode: "USD", Amount: "0.10"})
+	})
+
+	Convey("Float return the amount as float64", t, func() {
+		amount, err := Money{Amount: " 25.99 "}.Float()
+		So(err, ShouldBeNil)
+		So(amount, ShouldEqual, 25.99)
+
+		_, err = Money{}.Float()
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,149 @@
+package orders
+
+import "github.com/svvu/gomws/mws"
+
+// Money is a currency type and amount, see mws.Money.
+type Money = mws.Money
+
+// Address is the shipping address for the order.
+// http://docs.developer.amazonservices.com/en_US/orders/2013-09-01/Orders_Datatypes.html#Address
+type Address struct {
+	Name          string `xml:"Name"`
+	AddressLine1  string `xml:"AddressLine1"`
+	AddressLine2  string `xml:"AddressLine2"`
+	AddressLine3  string `xml:"AddressLine3"`
+	City          string `xml:"City"`
+	County        string `xml:"County"`
+	District      string `xml:"District"`
+	StateOrRegion string `xml:"StateOrRegion"`
+	PostalCode    string `xml:"PostalCode"`
+	CountryCode   string `xml:"CountryCode"`
+	Phone         string `xml:"Phone"`
+	AddressType   string `xml:"AddressType"`
+}
+
+// PaymentExecutionDetailItem information about a sub-payment method used to
+// pay for a COD order.
+type PaymentExecutionDetailItem struct {
+	Payment       Money  `xml:"Payment"`
+	PaymentMethod string `xml:"PaymentMethod"`
+}
+
+// TaxClassification the tax classification for the order.
+type TaxClassification struct {
+	Name  string `xml:"Name"`
+	Value string `xml:"Value"`
+}
+
+// BuyerTaxInfo tax information about the buyer.
+type BuyerTaxInfo struct {
+	CompanyLegalName   string              `xml:"CompanyLegalName"`
+	TaxingRegion       string              `xml:"TaxingRegion"`
+	TaxClassifications []TaxClassification `xml:"TaxClassifications>TaxClassification"`
+}
+
+// Order information.
+// http://docs.developer.amazonservices.com/en_US/orders/2013-09-01/Orders_Datatypes.html#Order
+type Order struct {
+	AmazonOrderId                string                       `xml:"AmazonOrderId"`
+	SellerOrderId                string                       `xml:"SellerOrderId"`
+	PurchaseDate                 mws.Timestamp                `xml:"PurchaseDate"`
+	LastUpdateDate               mws.Timestamp                `xml:"LastUpdateDate"`
+	OrderStatus                  string                       `xml:"OrderStatus"`
+	FulfillmentChannel           string                       `xml:"FulfillmentChannel"`
+	SalesChannel                 string                       `xml:"SalesChannel"`
+	OrderChannel                 string                       `xml:"OrderChannel"`
+	ShipServiceLevel             string                       `xml:"ShipServiceLevel"`
+	ShippingAddress              *Address                     `xml:"ShippingAddress"`
+	OrderTotal                   *Money                       `xml:"OrderTotal"`
+	NumberOfItemsShipped         int                          `xml:"NumberOfItemsShipped"`
+	NumberOfItemsUnshipped       int                          `xml:"NumberOfItemsUnshipped"`
+	PaymentExecutionDetail       []PaymentExecutionDetailItem `xml:"PaymentExecutionDetail>PaymentExecutionDetailItem"`
+	PaymentMethod                string                       `xml:"PaymentMethod"`
+	MarketplaceId                string                       `xml:"MarketplaceId"`
+	BuyerEmail                   string                       `xml:"BuyerEmail"`
+	BuyerName                    string                       `xml:"BuyerName"`
+	BuyerCounty                  string                       `xml:"BuyerCounty"`
+	BuyerTaxInfo                 *BuyerTaxInfo                `xml:"BuyerTaxInfo"`
+	ShipmentServiceLevelCategory string                       `xml:"ShipmentServiceLevelCategory"`
+	EasyShipShipmentStatus       string                       `xml:"EasyShipShipmentStatus"`
+	CbaDisplayableShippingLabel  string                       `xml:"CbaDisplayableShippingLabel"`
+	OrderType                    string                       `xml:"OrderType"`
+	EarliestShipDate             mws.Timestamp                `xml:"EarliestShipDate"`
+	LatestShipDate               mws.Timestamp                `xml:"LatestShipDate"`
+	EarliestDeliveryDate         mws.Timestamp                `xml:"EarliestDeliveryDate"`
+	LatestDeliveryDate           mws.Timestamp                `xml:"LatestDeliveryDate"`
+	IsBusinessOrder              bool                         `xml:"IsBusinessOrder"`
+	PurchaseOrderNumber          string                       `xml:"PurchaseOrderNumber"`
+	IsPrime                      bool                         `xml:"IsPrime"`
+	IsPremiumOrder               bool                         `xml:"IsPremiumOrder"`
+	PromiseResponseDueDate       mws.Timestamp                `xml:"PromiseResponseDueDate"`
+	IsEstimatedShipDateSet       bool                         `xml:"IsEstimatedShipDateSet"`
+	ShippedByAmazonTFM           bool                         `xml:"ShippedByAmazonTFM"`
+	TFMShipmentStatus            string                       `xml:"TFMShipmentStatus"`
+}
+
+// BuyerCustomizedInfo buyer information for custom orders.
+type BuyerCustomizedInfo struct {
+	CustomizedURL string `xml:"CustomizedURL"`
+}
+
+// PointsGranted the number and value of Amazon Points granted with the
+// purchase of an item.
+type PointsGranted struct {
+	PointsNumber        int   `xml:"PointsNumber"`
+	PointsMonetaryValue Money `xml:"PointsMonetaryValue"`
+}
+
+// OrderItem information.
+// http://docs.developer.amazonservices.com/en_US/orders/2013-09-01/Orders_Datatypes.html#OrderItem
+type OrderItem struct {
+	ASIN                       string               `xml:"ASIN"`
+	OrderItemId                string               `xml:"OrderItemId"`
+	SellerSKU                  string               `xml:"SellerSKU"`
+	BuyerCustomizedInfo        *BuyerCustomizedInfo `xml:"BuyerCustomizedInfo"`
+	Title                      string               `xml:"Title"`
+	QuantityOrdered            int                  `xml:"QuantityOrdered"`
+	QuantityShipped            int                  `xml:"QuantityShipped"`
+	PointsGranted              *PointsGranted       `xml:"PointsGranted"`
+	ItemPrice                  *Money               `xml:"ItemPrice"`
+	ShippingPrice              *Money               `xml:"ShippingPrice"`
+	GiftWrapPrice              *Money               `xml:"GiftWrapPrice"`
+	ItemTax                    *Money               `xml:"ItemTax"`
+	ShippingTax                *Money               `xml:"ShippingTax"`
+	GiftWrapTax                *Money               `xml:"GiftWrapTax"`
+	ShippingDiscount           *Money               `xml:"ShippingDiscount"`
+	PromotionDiscount          *Money               `xml:"PromotionDiscount"`
+	PromotionIds               []string             `xml:"PromotionIds>PromotionId"`
+	CODFee                     *Money               `xml:"CODFee"`
+	CODFeeDiscount             *Money               `xml:"CODFeeDiscount"`
+	IsGift                     bool                 `xml:"IsGift"`
+	GiftMessageText            string               `xml:"GiftMessageText"`
+	GiftWrapLevel              string               `xml:"GiftWrapLevel"`
+	ConditionNote              string               `xml:"ConditionNote"`
+	ConditionId                string               `xml:"ConditionId"`
+	ConditionSubtypeId         string               `xml:"ConditionSubtypeId"`
+	ScheduledDeliveryStartDate mws.Timestamp        `xml:"ScheduledDeliveryStartDate"`
+	ScheduledDeliveryEndDate   mws.Timestamp        `xml:"ScheduledDeliveryEndDate"`
+	PriceDesignation           string               `xml:"PriceDesignation"`
+}
+
+// ListOrdersResult is the result of ListOrders and ListOrdersByNextToken.
+type ListOrdersResult struct {
+	NextToken         string        `xml:"NextToken"`
+	CreatedBefore     mws.Timestamp `xml:"CreatedBefore"`
+	LastUpdatedBefore mws.Timestamp `xml:"LastUpdatedBefore"`
+	Orders            []Order       `xml:"Orders>Order"`
+}
+
+// GetOrderResult is the result of GetOrder.
+type GetOrderResult struct {
+	Orders []Order `xml:"Orders>Order"`
+}
+
+// ListOrderItemsResult is the result of ListOrderItems and ListOrderItemsByNextToken.
+type ListOrderItemsResult struct {
+	NextToken     string      `xml:"NextToken"`
+	AmazonOrderId string      `xml:"AmazonOrderId"`
+	OrderItems    []OrderItem `xml:"OrderItems>OrderItem"`
+}
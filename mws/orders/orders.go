@@ -3,6 +3,8 @@
 package orders
 
 import (
+	"context"
+
 	"github.com/svvu/gomws/mws"
 )
 
@@ -22,6 +24,17 @@ func NewClient(config mws.Config) (*Orders, error) {
 	return orders, nil
 }
 
+// NewPoolClient return the orders client for the seller in the marketplace from the pool.
+func NewPoolClient(pool *mws.Pool, sellerID, marketplace string) (*Orders, error) {
+	orders := new(Orders)
+	base, err := pool.Client(sellerID, marketplace, orders.Version(), orders.Name())
+	if err != nil {
+		return nil, err
+	}
+	orders.Client = base
+	return orders, nil
+}
+
 // Version return the current version of api
 func (o Orders) Version() string {
 	return "2013-09-01"
@@ -35,17 +48,23 @@ func (o Orders) Name() string {
 // GetServiceStatus Returns the operational status of the Orders API section.
 // http://docs.developer.amazonservices.com/en_US/orders/2013-09-01/MWS_GetServiceStatus.html
 func (o Orders) GetServiceStatus() (*mws.Response, error) {
+	return o.GetServiceStatusContext(context.Background())
+}
+
+// GetServiceStatusContext is the context version of GetServiceStatus.
+func (o Orders) GetServiceStatusContext(ctx context.Context) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action": "GetServiceStatus",
 	}
 
-	return o.SendRequest(params)
+	return o.SendRequestContext(ctx, params)
 }
 
 // ListOrders Returns orders created or updated during a time frame that you specify.
 //
 // Note: When calling this operation, either CreatedAfter or LastUpdatedAfter must be specify.
 // Specify both will return an error.
+// The orders of all the marketplaces set in the config are returned.
 //
 // other params:
 // 	CreatedAfter - string, ISO-8601 date format.
@@ -76,6 +95,11 @@ func (o Orders) GetServiceStatus() (*mws.Response, error) {
 // 		Values: PendingPickUp, LabelCanceled, PickedUp, AtDestinationFC,
 // 		Delivered, RejectedByBuyer, Undeliverable, ReturnedToSeller, Lost.
 func (o Orders) ListOrders(others ...mws.Parameters) (*mws.Response, error) {
+	return o.ListOrdersContext(context.Background(), others...)
+}
+
+// ListOrdersContext is the context version of ListOrders.
+func (o Orders) ListOrdersContext(ctx context.Context, others ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{
 		"CreatedAfter", "CreatedBefore",
 		"LastUpdatedAfter", "LastUpdatedBefore",
@@ -85,7 +109,7 @@ func (o Orders) ListOrders(others ...mws.Parameters) (*mws.Response, error) {
 	}, others)
 	params := mws.Parameters{
 		"Action":        "ListOrders",
-		"MarketplaceId": []string{o.MarketPlaceId},
+		"MarketplaceId": o.MarketplaceIds(),
 	}.Merge(op)
 
 	structuredParams := params.StructureKeys("MarketplaceId", "Id")
@@ -102,51 +126,71 @@ func (o Orders) ListOrders(others ...mws.Parameters) (*mws.Response, error) {
 		structuredParams = params.StructureKeys("TFMShipmentStatus", "Status")
 	}
 
-	return o.SendRequest(structuredParams)
+	return o.SendRequestContext(ctx, structuredParams)
 }
 
 // ListOrdersByNextToken Returns the next page of orders using the NextToken parameter.
 // http://docs.developer.amazonservices.com/en_US/orders/2013-09-01/Orders_ListOrdersByNextToken.html
 func (o Orders) ListOrdersByNextToken(nextToken string) (*mws.Response, error) {
+	return o.ListOrdersByNextTokenContext(context.Background(), nextToken)
+}
+
+// ListOrdersByNextTokenContext is the context version of ListOrdersByNextToken.
+func (o Orders) ListOrdersByNextTokenContext(ctx context.Context, nextToken string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":    "ListOrdersByNextToken",
 		"NextToken": nextToken,
 	}
 
-	return o.SendRequest(params)
+	return o.SendRequestContext(ctx, params)
 }
 
 // GetOrder Returns orders based on the AmazonOrderId values that you specify.
 // Maximum 50 ids.
 // http://docs.developer.amazonservices.com/en_US/orders/2013-09-01/Orders_GetOrder.html
 func (o Orders) GetOrder(amazonOrderIds []string) (*mws.Response, error) {
+	return o.GetOrderContext(context.Background(), amazonOrderIds)
+}
+
+// GetOrderContext is the context version of GetOrder.
+func (o Orders) GetOrderContext(ctx context.Context, amazonOrderIds []string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":        "GetOrder",
 		"AmazonOrderId": amazonOrderIds,
 	}
 	structuredParams := params.StructureKeys("AmazonOrderId", "Id")
 
-	return o.SendRequest(structuredParams)
+	return o.SendRequestContext(ctx, structuredParams)
 }
 
 // ListOrderItems Returns order items based on the AmazonOrderId that you specify.
 // http://docs.developer.amazonservices.com/en_US/orders/2013-09-01/Orders_ListOrderItems.html
 func (o Orders) ListOrderItems(amazonOrderID string) (*mws.Response, error) {
+	return o.ListOrderItemsContext(context.Background(), amazonOrderID)
+}
+
+// ListOrderItemsContext is the context version of ListOrderItems.
+func (o Orders) ListOrderItemsContext(ctx context.Context, amazonOrderID string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":        "ListOrderItems",
 		"AmazonOrderId": amazonOrderID,
 	}
 
-	return o.SendRequest(params)
+	return o.SendRequestContext(ctx, params)
 }
 
 // ListOrderItemsByNextToken Returns the next page of order items using the NextToken parameter.
 // http://docs.developer.amazonservices.com/en_US/orders/2013-09-01/Orders_ListOrderItemsByNextToken.html
 func (o Orders) ListOrderItemsByNextToken(nextToken string) (*mws.Response, error) {
+	return o.ListOrderItemsByNextTokenContext(context.Background(), nextToken)
+}
+
+// ListOrderItemsByNextTokenContext is the context version of ListOrderItemsByNextToken.
+func (o Orders) ListOrderItemsByNextTokenContext(ctx context.Context, nextToken string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":    "ListOrderItemsByNextToken",
 		"NextToken": nextToken,
 	}
 
-	return o.SendRequest(params)
+	return o.SendRequestContext(ctx, params)
 }
@@ -0,0 +1,19 @@
+package orders
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+)
+
+func TestNewPoolClient(t *testing.T) {
+	pool := mws.NewPool(mws.StaticProvider{Credential: mws.Credential{AccessKey: "AccessKey", SecretKey: "SecretKey"}})
+	pool.Authorize("SellerID", "AuthToken")
+
+	Convey("Orders client of the seller from the pool", t, func() {
+		client, err := NewPoolClient(pool, "SellerID", "UK")
+		So(err, ShouldBeNil)
+		So(client.Path(), ShouldEqual, "/Orders/2013-09-01")
+	})
+}
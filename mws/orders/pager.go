@@ -0,0 +1,35 @@
+package orders
+
+import (
+	"context"
+
+	"github.com/svvu/gomws/mws"
+)
+
+// ListOrdersPager create a pager for ListOrders, the items are *Order.
+// The parameters are the same as ListOrders.
+func (o Orders) ListOrdersPager(others ...mws.Parameters) *mws.Pager {
+	fetch := func(ctx context.Context, nextToken string) (*mws.Response, error) {
+		if nextToken == "" {
+			return o.ListOrdersContext(ctx, others...)
+		}
+		return o.ListOrdersByNextTokenContext(ctx, nextToken)
+	}
+
+	newOrder := func() interface{} { return &Order{} }
+	return mws.NewPager(fetch, "Order", newOrder).Pace(o.Client, "ListOrdersByNextToken")
+}
+
+// ListOrderItemsPager create a pager for ListOrderItems of the order, the
+// 	items are *OrderItem.
+func (o Orders) ListOrderItemsPager(amazonOrderID string) *mws.Pager {
+	fetch := func(ctx context.Context, nextToken string) (*mws.Response, error) {
+		if nextToken == "" {
+			return o.ListOrderItemsContext(ctx, amazonOrderID)
+		}
+		return o.ListOrderItemsByNextTokenContext(ctx, nextToken)
+	}
+
+	newItem := func() interface{} { return &OrderItem{} }
+	return mws.NewPager(fetch, "OrderItem", newItem).Pace(o.Client, "ListOrderItemsByNextToken")
+}
@@ -0,0 +1,86 @@
+package orders
+
+import (
+	"context"
+
+	"github.com/svvu/gomws/mws"
+)
+
+// ListOrdersResult call ListOrders and decode the orders in the response.
+// The parameters are the same as ListOrders.
+func (o Orders) ListOrdersResult(ctx context.Context, others ...mws.Parameters) (*ListOrdersResult, error) {
+	resp, err := o.ListOrdersContext(ctx, others...)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	result := &ListOrdersResult{}
+	if err := resp.DecodeResult("ListOrdersResult", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListOrdersByNextTokenResult call ListOrdersByNextToken and decode the orders
+// in the response.
+func (o Orders) ListOrdersByNextTokenResult(ctx context.Context, nextToken string) (*ListOrdersResult, error) {
+	resp, err := o.ListOrdersByNextTokenContext(ctx, nextToken)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	result := &ListOrdersResult{}
+	if err := resp.DecodeResult("ListOrdersByNextTokenResult", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetOrderResult call GetOrder and decode the orders in the response.
+func (o Orders) GetOrderResult(ctx context.Context, amazonOrderIds []string) (*GetOrderResult, error) {
+	resp, err := o.GetOrderContext(ctx, amazonOrderIds)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	result := &GetOrderResult{}
+	if err := resp.DecodeResult("GetOrderResult", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListOrderItemsResult call ListOrderItems and decode the order items in
+// the response.
+func (o Orders) ListOrderItemsResult(ctx context.Context, amazonOrderID string) (*ListOrderItemsResult, error) {
+	resp, err := o.ListOrderItemsContext(ctx, amazonOrderID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	result := &ListOrderItemsResult{}
+	if err := resp.DecodeResult("ListOrderItemsResult", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListOrderItemsByNextTokenResult call ListOrderItemsByNextToken and decode
+// the order items in the response.
+func (o Orders) ListOrderItemsByNextTokenResult(ctx context.Context, nextToken string) (*ListOrderItemsResult, error) {
+	resp, err := o.ListOrderItemsByNextTokenContext(ctx, nextToken)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	result := &ListOrderItemsResult{}
+	if err := resp.DecodeResult("ListOrderItemsByNextTokenResult", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,158 @@
+package orders
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+	"github.com/svvu/gomws/mws/mock"
+)
+
+func testClient(server *mock.Server) *Orders {
+	client, _ := NewClient(mws.Config{
+		SellerId:  "SellerID",
+		AuthToken: "AuthToken",
+		Region:    "JP",
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+	})
+	client.Host = server.Host()
+	client.Transport = mock.NoVerifyTransport()
+	return client
+}
+
+func exampleResponse(name string) *http.Response {
+	body, _ := ioutil.ReadFile("./exampleResponses/" + name + ".xml")
+	return mock.NewResponse(200, string(body))
+}
+
+func TestOrders_ListOrdersResult(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	client := testClient(server)
+
+	Convey("Decode ListOrders response", t, func() {
+		server.SetResponse(exampleResponse("ListOrders"))
+
+		result, err := client.ListOrdersResult(context.Background())
+
+		So(err, ShouldBeNil)
+		So(result.NextToken, ShouldEqual, "2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=")
+		So(len(result.Orders), ShouldEqual, 2)
+
+		order := result.Orders[0]
+		So(order.AmazonOrderId, ShouldEqual, "902-3159896-1390916")
+		So(order.OrderStatus, ShouldEqual, "Pending")
+		So(order.IsBusinessOrder, ShouldBeTrue)
+		So(order.IsPrime, ShouldBeFalse)
+		So(order.PurchaseOrderNumber, ShouldEqual, "PO12345678")
+		So(order.NumberOfItemsUnshipped, ShouldEqual, 0)
+
+		purchaseDate, err := order.PurchaseDate.Time()
+		So(err, ShouldBeNil)
+		So(purchaseDate.Year(), ShouldEqual, 2013)
+	})
+
+	Convey("Decode ListOrdersByNextToken response", t, func() {
+		server.SetResponse(exampleResponse("ListOrdersByNextToken"))
+
+		result, err := client.ListOrdersByNextTokenResult(context.Background(), "token")
+
+		So(err, ShouldBeNil)
+		So(result.NextToken, ShouldEqual, "2YgYW55IGNhcm5hbCBwbGVhc3VyZS4=")
+		So(len(result.Orders), ShouldBeGreaterThan, 0)
+		So(result.Orders[0].AmazonOrderId, ShouldEqual, "902-3159896-1390916")
+	})
+
+	Convey("Return error when request not success", t, func() {
+		server.SetResponse(mock.NewResponse(503,
+			"<ErrorResponse><Error><Code>RequestThrottled</Code></Error></ErrorResponse>"))
+
+		result, err := client.ListOrdersResult(context.Background())
+
+		So(result, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestOrders_GetOrderResult(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	client := testClient(server)
+	server.SetResponse(exampleResponse("GetOrder"))
+
+	Convey("Decode GetOrder response", t, func() {
+		result, err := client.GetOrderResult(context.Background(), []string{"058-1233752-8214740"})
+
+		So(err, ShouldBeNil)
+		So(len(result.Orders), ShouldEqual, 1)
+
+		order := result.Orders[0]
+		So(order.AmazonOrderId, ShouldEqual, "058-1233752-8214740")
+		So(*order.OrderTotal, ShouldResemble, Money{CurrencyCode: "JPY", Amount: "1507.00"})
+		So(*order.ShippingAddress, ShouldResemble, Address{
+			Name:         "Jane Smith",
+			AddressLine1: "1-2-10 Akasaka",
+			City:         "Tokyo",
+			PostalCode:   "107-0053",
+			CountryCode:  "JP",
+		})
+		So(order.NumberOfItemsUnshipped, ShouldEqual, 1)
+		So(order.PaymentExecutionDetail, ShouldResemble, []PaymentExecutionDetailItem{
+			{Payment: Money{CurrencyCode: "JPY", Amount: "10.00"}, PaymentMethod: "PointsAccount"},
+			{Payment: Money{CurrencyCode: "JPY", Amount: "317.00"}, PaymentMethod: "GC"},
+			{Payment: Money{CurrencyCode: "JPY", Amount: "1180.00"}, PaymentMethod: "COD"},
+		})
+		So(order.PaymentMethod, ShouldEqual, "COD")
+
+		lastUpdate, err := order.LastUpdateDate.Time()
+		So(err, ShouldBeNil)
+		So(lastUpdate.Day(), ShouldEqual, 7)
+	})
+}
+
+func TestOrders_ListOrderItemsResult(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	client := testClient(server)
+
+	Convey("Decode ListOrderItems response", t, func() {
+		server.SetResponse(exampleResponse("ListOrderItems"))
+
+		result, err := client.ListOrderItemsResult(context.Background(), "058-1233752-8214740")
+
+		So(err, ShouldBeNil)
+		So(result.AmazonOrderId, ShouldEqual, "058-1233752-8214740")
+		So(result.NextToken, ShouldEqual, "MRgZW55IGNhcm5hbCBwbGVhc3VyZS6=")
+		So(len(result.OrderItems), ShouldEqual, 2)
+
+		item := result.OrderItems[0]
+		So(item.ASIN, ShouldEqual, "BT0093TELA")
+		So(item.QuantityOrdered, ShouldEqual, 1)
+		So(item.BuyerCustomizedInfo, ShouldNotBeNil)
+		So(*item.PointsGranted, ShouldResemble, PointsGranted{
+			PointsNumber:        10,
+			PointsMonetaryValue: Money{CurrencyCode: "JPY", Amount: "10.00"},
+		})
+		So(*item.ItemPrice, ShouldResemble, Money{CurrencyCode: "JPY", Amount: "25.99"})
+		price, err := item.ItemPrice.Float()
+		So(err, ShouldBeNil)
+		So(price, ShouldEqual, 25.99)
+		So(item.GiftWrapLevel, ShouldEqual, "Classic")
+
+		So(result.OrderItems[1].PromotionIds, ShouldResemble, []string{"FREESHIP"})
+		So(result.OrderItems[1].ConditionId, ShouldEqual, "Used")
+	})
+
+	Convey("Decode ListOrderItemsByNextToken response", t, func() {
+		server.SetResponse(exampleResponse("ListOrderItemsByNextToken"))
+
+		result, err := client.ListOrderItemsByNextTokenResult(context.Background(), "token")
+
+		So(err, ShouldBeNil)
+		So(len(result.OrderItems), ShouldBeGreaterThan, 0)
+	})
+}
@@ -0,0 +1,216 @@
+package mws
+
+import (
+	"context"
+	"io/ioutil"
+
+	"github.com/svvu/gomws/xmlParser"
+)
+
+// PageFunc fetch a page of the list operation.
+// The nextToken is empty for the first page, the list operation should be
+// 	called, otherwise the ByNextToken operation should be called.
+type PageFunc func(ctx context.Context, nextToken string) (*Response, error)
+
+// Pager walk through all the pages of a list operation by the NextToken.
+//
+// Pages can be fetched one by one:
+// 	for pager.HasNextPage() {
+// 		orders, err := pager.NextValues(ctx)
+// 	}
+// Or iterate the items one by one:
+// 	for pager.Next(ctx) {
+// 		order := pager.Value().(*orders.Order)
+// 	}
+// 	err := pager.Err()
+type Pager struct {
+	// Throttler pace the ByNextToken pages, the burst of the quota allow
+	// 	the first pages to be fetched without waiting. See Pace.
+	Throttler *Throttler
+
+	fetch     PageFunc
+	itemKey   string
+	newItem   func() interface{}
+	name      string
+	action    string
+	sellerID  string
+	started   bool
+	nextToken string
+	body      []byte
+	err       error
+
+	items  []xmlParser.XMLNode
+	values []interface{}
+	item   xmlParser.XMLNode
+	value  interface{}
+}
+
+// NewPager create a pager for the list operation.
+// itemKey is the tag of the items in the page, ex: "Order".
+// newItem return the pointer of a new item to decode the item element into,
+// 	ex: &Order{}. If nil, only the xml nodes of the items are available.
+func NewPager(fetch PageFunc, itemKey string, newItem func() interface{}) *Pager {
+	return &Pager{fetch: fetch, itemKey: itemKey, newItem: newItem}
+}
+
+// Pace pace the pages by the quota of the ByNextToken action of the client.
+// If the client has a Throttler, the requests are already paced by it,
+// 	otherwise the pager use a new Throttler with the DefaultRequestQuotas.
+func (p *Pager) Pace(client *Client, action string) *Pager {
+	p.name, p.action, p.sellerID = client.Name, action, client.SellerId
+	if client.Throttler == nil {
+		p.Throttler = NewThrottler()
+	}
+	return p
+}
+
+// HasNextPage check whether or not there are more pages to fetch.
+func (p *Pager) HasNextPage() bool {
+	return p.err == nil && (!p.started || p.nextToken != "")
+}
+
+// NextToken return the token for the next page.
+func (p *Pager) NextToken() string {
+	return p.nextToken
+}
+
+// NextPage fetch the next page and return the item nodes in the page.
+// The pager wait for the Throttler before fetching the ByNextToken pages.
+// Once an error returned, the pager stop.
+func (p *Pager) NextPage(ctx context.Context) ([]xmlParser.XMLNode, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if !p.HasNextPage() {
+		p.body = nil
+		return []xmlParser.XMLNode{}, nil
+	}
+
+	if p.started && p.Throttler != nil {
+		if err := p.Throttler.Wait(ctx, p.name, p.action, p.sellerID); err != nil {
+			p.err = err
+			return nil, err
+		}
+	}
+
+	parser, err := p.fetchPage(ctx)
+	if err != nil {
+		p.err = err
+		return nil, err
+	}
+
+	p.started = true
+	p.nextToken = pageNextToken(parser)
+
+	return parser.FindByKey(p.itemKey), nil
+}
+
+// NextValues fetch the next page and return the items decoded by newItem,
+// 	ex: []interface{}{&Order{}, &Order{}}.
+func (p *Pager) NextValues(ctx context.Context) ([]interface{}, error) {
+	if _, err := p.NextPage(ctx); err != nil {
+		return nil, err
+	}
+	values, err := p.decodePage()
+	if err != nil {
+		p.err = err
+	}
+	return values, err
+}
+
+// PageBody return the raw xml body of the last page fetched.
+func (p *Pager) PageBody() []byte {
+	return p.body
+}
+
+// Next advance the pager to the next item, fetch the next page if needed.
+// False will be returned if no more items or an error occurred.
+func (p *Pager) Next(ctx context.Context) bool {
+	for len(p.items) == 0 {
+		if !p.HasNextPage() {
+			return false
+		}
+		items, err := p.NextPage(ctx)
+		if err != nil {
+			return false
+		}
+		values, err := p.decodePage()
+		if err != nil {
+			p.err = err
+			return false
+		}
+		p.items, p.values = items, values
+	}
+
+	p.item, p.items = p.items[0], p.items[1:]
+	if len(p.values) > 0 {
+		p.value, p.values = p.values[0], p.values[1:]
+	}
+	return true
+}
+
+// Item return the current item node.
+func (p *Pager) Item() xmlParser.XMLNode {
+	return p.item
+}
+
+// Value return the current item decoded by newItem, nil if no newItem.
+func (p *Pager) Value() interface{} {
+	return p.value
+}
+
+// Err return the error stop the pager.
+func (p *Pager) Err() error {
+	return p.err
+}
+
+// decodePage decode the items of the last page with newItem.
+func (p *Pager) decodePage() ([]interface{}, error) {
+	if p.newItem == nil || p.body == nil {
+		return nil, nil
+	}
+	values := []interface{}{}
+	err := UnmarshalElements(p.body, p.itemKey, func() interface{} {
+		values = append(values, p.newItem())
+		return values[len(values)-1]
+	})
+	return values, err
+}
+
+// fetchPage send the request for the page and parse the body.
+func (p *Pager) fetchPage(ctx context.Context) (*ResultParser, error) {
+	resp, err := p.fetch(ctx, p.nextToken)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	p.body = body
+
+	return NewResultParser(body)
+}
+
+// pageNextToken get the NextToken of the page.
+// If the page has HasNext tag with false value, empty string will be returned.
+func pageNextToken(parser *ResultParser) string {
+	if hasNext := parser.FindByKey("HasNext"); len(hasNext) > 0 {
+		if ok, err := hasNext[0].ToBool(); err == nil && !ok {
+			return ""
+		}
+	}
+
+	tokens := parser.FindByKey("NextToken")
+	if len(tokens) == 0 {
+		return ""
+	}
+	token, _ := tokens[0].ToString()
+	return token
+}
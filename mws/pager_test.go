@@ -0,0 +1,189 @@
+package mws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws/mock"
+)
+
+// testPagerClient is the client the test pagers paced by.
+var testPagerClient = &Client{Name: "Test", SellerId: "SellerID"}
+
+// testPages create a page func serve the pages, each page has 2 items.
+func testPages(pages int, tokens *[]string) PageFunc {
+	return func(ctx context.Context, nextToken string) (*Response, error) {
+		*tokens = append(*tokens, nextToken)
+		page := len(*tokens)
+		next := ""
+		if page < pages {
+			next = fmt.Sprintf("<NextToken>token%d</NextToken>", page)
+		}
+		body := fmt.Sprintf(
+			"<Result>%v<Items><Item>%d-1</Item><Item>%d-2</Item></Items></Result>",
+			next, page, page,
+		)
+		return NewResponse(mock.NewResponse(200, body)), nil
+	}
+}
+
+func TestPager_NextPage(t *testing.T) {
+	Convey("When walk through all pages", t, func() {
+		tokens := []string{}
+		pager := NewPager(testPages(3, &tokens), "Item", nil)
+
+		pages := [][]string{}
+		for pager.HasNextPage() {
+			nodes, err := pager.NextPage(context.Background())
+			So(err, ShouldBeNil)
+			items := []string{}
+			for _, node := range nodes {
+				item, _ := node.ToString()
+				items = append(items, item)
+			}
+			pages = append(pages, items)
+		}
+
+		Convey("All pages fetched", func() {
+			So(pages, ShouldResemble, [][]string{
+				{"1-1", "1-2"}, {"2-1", "2-2"}, {"3-1", "3-2"},
+			})
+		})
+
+		Convey("Next token used for subsequent pages", func() {
+			So(tokens, ShouldResemble, []string{"", "token1", "token2"})
+		})
+	})
+
+	Convey("When paced by the ByNextToken quota", t, func() {
+		tokens := []string{}
+		pager := NewPager(testPages(3, &tokens), "Item", nil).Pace(testPagerClient, "ListItemsByNextToken")
+		pager.Throttler.SetQuota("Test", "ListItemsByNextToken", RequestQuota{
+			MaxRequests: 1, RestoreRate: 50 * time.Millisecond,
+		})
+
+		start := time.Now()
+		pager.NextPage(context.Background())
+		pager.NextPage(context.Background())
+		burst := time.Since(start)
+		pager.NextPage(context.Background())
+
+		Convey("Pages within the burst are not paced", func() {
+			So(burst, ShouldBeLessThan, 50*time.Millisecond)
+		})
+
+		Convey("Pages beyond the burst are paced by the restore rate", func() {
+			So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 50*time.Millisecond)
+			So(len(tokens), ShouldEqual, 3)
+		})
+	})
+
+	Convey("When the client has a throttler", t, func() {
+		client := &Client{Name: "Test", SellerId: "SellerID", Throttler: NewThrottler()}
+		pager := NewPager(testPages(3, &[]string{}), "Item", nil).Pace(client, "ListItemsByNextToken")
+
+		Convey("Pages are paced by the client", func() {
+			So(pager.Throttler, ShouldBeNil)
+		})
+	})
+
+	Convey("When context canceled", t, func() {
+		tokens := []string{}
+		pager := NewPager(testPages(3, &tokens), "Item", nil).Pace(testPagerClient, "ListItemsByNextToken")
+		pager.Throttler.SetQuota("Test", "ListItemsByNextToken", RequestQuota{
+			MaxRequests: 1, RestoreRate: time.Hour,
+		})
+		pager.NextPage(context.Background())
+		pager.NextPage(context.Background())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := pager.NextPage(ctx)
+
+		Convey("Context error returned and pager stopped", func() {
+			So(err, ShouldEqual, context.Canceled)
+			So(pager.HasNextPage(), ShouldBeFalse)
+			So(len(tokens), ShouldEqual, 2)
+		})
+	})
+
+	Convey("When response has error", t, func() {
+		pager := NewPager(func(ctx context.Context, nextToken string) (*Response, error) {
+			return NewResponse(mock.NewResponse(503, throttledBody)), nil
+		}, "Item", nil)
+
+		_, err := pager.NextPage(context.Background())
+
+		Convey("Response error returned", func() {
+			So(errors.Is(err, ErrThrottled), ShouldBeTrue)
+			So(pager.Err(), ShouldEqual, err)
+		})
+	})
+
+	Convey("When page has HasNext false", t, func() {
+		pager := NewPager(func(ctx context.Context, nextToken string) (*Response, error) {
+			return NewResponse(mock.NewResponse(200,
+				"<Result><HasNext>false</HasNext><NextToken>token</NextToken></Result>",
+			)), nil
+		}, "Item", nil)
+
+		pager.NextPage(context.Background())
+
+		Convey("No more pages", func() {
+			So(pager.HasNextPage(), ShouldBeFalse)
+		})
+	})
+}
+
+func TestPager_Next(t *testing.T) {
+	Convey("Iterate items across pages", t, func() {
+		tokens := []string{}
+		pager := NewPager(testPages(2, &tokens), "Item", nil)
+
+		items := []string{}
+		for pager.Next(context.Background()) {
+			item := pager.Item()
+			value, _ := item.ToString()
+			items = append(items, value)
+		}
+
+		So(pager.Err(), ShouldBeNil)
+		So(items, ShouldResemble, []string{"1-1", "1-2", "2-1", "2-2"})
+	})
+}
+
+func TestPager_Value(t *testing.T) {
+	newItem := func() interface{} { return new(string) }
+
+	Convey("Decode the items of the page", t, func() {
+		pager := NewPager(testPages(2, &[]string{}), "Item", newItem)
+
+		values, err := pager.NextValues(context.Background())
+		So(err, ShouldBeNil)
+		So(values, ShouldHaveLength, 2)
+		So(*values[1].(*string), ShouldEqual, "1-2")
+	})
+
+	Convey("Iterate the decoded items across pages", t, func() {
+		pager := NewPager(testPages(2, &[]string{}), "Item", newItem)
+
+		items := []string{}
+		for pager.Next(context.Background()) {
+			items = append(items, *pager.Value().(*string))
+		}
+
+		So(pager.Err(), ShouldBeNil)
+		So(items, ShouldResemble, []string{"1-1", "1-2", "2-1", "2-2"})
+	})
+
+	Convey("Value is nil without newItem", t, func() {
+		pager := NewPager(testPages(1, &[]string{}), "Item", nil)
+
+		So(pager.Next(context.Background()), ShouldBeTrue)
+		So(pager.Value(), ShouldBeNil)
+	})
+}
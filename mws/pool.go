@@ -0,0 +1,165 @@
+package mws
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// ErrSellerNotAuthorized is returned when the seller is not authorized in
+// 	the pool, or the authorization is revoked.
+var ErrSellerNotAuthorized = errors.New("Seller not authorized")
+
+// Pool hand out the clients for the sellers authorized the developer.
+// All the clients share the developer credential, the http client and the
+// 	throttler. The throttler track the quotas per seller, so one seller will
+// 	not slow down the others.
+// The clients are created once per seller, marketplace and API, and reused
+// 	after, set the fields of the pool before handing out the clients.
+// Sellers can be authorized and revoked at any time, the clients handed out
+// 	use the latest authorization on each request.
+// Safe for concurrent use.
+type Pool struct {
+	// Endpoint of the clients, ex: a proxy or the mock server url, see
+	// 	Config.Endpoint. Default to the endpoint of the marketplace region.
+	Endpoint string
+	// Http client shared by the clients to reuse the connections, set its
+	// 	Transport to customize the transport.
+	HTTPClient *http.Client
+	// Throttler shared by the clients, nil to not throttle.
+	// Default to NewThrottler().
+	Throttler *Throttler
+	// Retry policy of the clients, nil to not retry.
+	Retry *RetryPolicy
+	// Middlewares of the clients, ex: logging and metrics.
+	Middlewares []Middleware
+
+	credentials *Credentials
+
+	mu      sync.RWMutex
+	sellers map[string]string
+	clients map[poolKey]*Client
+}
+
+// poolKey is the key of the clients in the pool.
+type poolKey struct {
+	sellerID      string
+	marketplaceID string
+	name          string
+	version       string
+}
+
+// NewPool create a pool with the developer credential from the provider.
+// Default to the env variables if the provider is nil.
+func NewPool(provider CredentialProvider) *Pool {
+	if provider == nil {
+		provider = EnvProvider{}
+	}
+
+	return &Pool{
+		HTTPClient:  new(http.Client),
+		Throttler:   NewThrottler(),
+		credentials: NewCredentials(provider),
+		sellers:     map[string]string{},
+		clients:     map[poolKey]*Client{},
+	}
+}
+
+// Authorize add or update the authorization of the seller.
+func (p *Pool) Authorize(sellerID, authToken string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sellers[sellerID] = authToken
+}
+
+// Revoke remove the authorization and the clients of the seller, the
+// 	requests of the clients handed out will fail with ErrSellerNotAuthorized.
+func (p *Pool) Revoke(sellerID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.sellers, sellerID)
+	for key := range p.clients {
+		if key.sellerID == sellerID {
+			delete(p.clients, key)
+		}
+	}
+}
+
+// Sellers return the ids of the sellers authorized, in order.
+func (p *Pool) Sellers() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	sellers := make([]string, 0, len(p.sellers))
+	for sellerID := range p.sellers {
+		sellers = append(sellers, sellerID)
+	}
+	sort.Strings(sellers)
+	return sellers
+}
+
+// authToken return the auth token of the seller.
+func (p *Pool) authToken(sellerID string) (string, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	authToken, ok := p.sellers[sellerID]
+	if !ok {
+		return "", fmt.Errorf("%w: %v", ErrSellerNotAuthorized, sellerID)
+	}
+	return authToken, nil
+}
+
+// Client return the client of the API for the seller in the marketplace.
+// The marketplace can be the id, country code or domain, see
+// 	LookupMarketplace. The client is shared by the callers with the same
+// 	seller, marketplace and API, it should not be modified.
+// The API packages wrap it by NewPoolClient, ex: orders.NewPoolClient.
+func (p *Pool) Client(sellerID, marketplace, version, name string) (*Client, error) {
+	authToken, err := p.authToken(sellerID)
+	if err != nil {
+		return nil, err
+	}
+	mp, ok := LookupMarketplace(marketplace)
+	if !ok {
+		return nil, fmt.Errorf("Invalid marketplace: %v", marketplace)
+	}
+
+	key := poolKey{sellerID: sellerID, marketplaceID: mp.ID, name: name, version: version}
+	p.mu.RLock()
+	base, ok := p.clients[key]
+	p.mu.RUnlock()
+	if ok {
+		return base, nil
+	}
+
+	config := Config{
+		SellerId:     sellerID,
+		AuthToken:    authToken,
+		Marketplaces: []string{mp.ID},
+		Endpoint:     p.Endpoint,
+		Credentials:  p.credentials.provider,
+	}
+	base, err = NewClient(config, version, name)
+	if err != nil {
+		return nil, err
+	}
+
+	base.credentials = p.credentials
+	base.Client = p.HTTPClient
+	base.Throttler = p.Throttler
+	base.Retry = p.Retry
+	base.Middlewares = append([]Middleware{}, p.Middlewares...)
+	base.pool = p
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Another caller may create the client in the meantime.
+	if cached, ok := p.clients[key]; ok {
+		return cached, nil
+	}
+	p.clients[key] = base
+	return base, nil
+}
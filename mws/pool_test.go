@@ -0,0 +1,153 @@
+package mws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws/mock"
+)
+
+func TestPool(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	server.TimestampTolerance = 0
+	server.SetCredential(testAccessKey, testSecretKey)
+
+	var mu sync.Mutex
+	received := map[string]string{}
+	server.HandleAction("Orders", "GetOrder", func(req *mock.Request) *http.Response {
+		mu.Lock()
+		defer mu.Unlock()
+		received[req.Params.Get("SellerId")] = req.Params.Get("MWSAuthToken")
+		return mock.NewResponse(200, "<GetOrderResponse/>")
+	})
+
+	newPool := func() *Pool {
+		pool := NewPool(StaticProvider{Credential{AccessKey: testAccessKey, SecretKey: testSecretKey}})
+		pool.Endpoint = server.URL
+		pool.HTTPClient.Transport = mock.NoVerifyTransport()
+		pool.Authorize("SellerA", "TokenA")
+		pool.Authorize("SellerB", "TokenB")
+		return pool
+	}
+	pool := newPool()
+
+	client := func(sellerID string) *Client {
+		c, err := pool.Client(sellerID, "ATVPDKIKX0DER", "2013-09-01", "Orders")
+		So(err, ShouldBeNil)
+		return c
+	}
+
+	Convey("Hand out the clients of the sellers", t, func() {
+		So(pool.Sellers(), ShouldResemble, []string{"SellerA", "SellerB"})
+
+		clientA, clientB := client("SellerA"), client("SellerB")
+		So(clientA.Client, ShouldEqual, clientB.Client)
+		So(clientA.Throttler, ShouldEqual, clientB.Throttler)
+
+		for _, c := range []*Client{clientA, clientB} {
+			resp, err := c.SendRequest(Parameters{"Action": "GetOrder"})
+			So(err, ShouldBeNil)
+			So(resp.Error, ShouldBeNil)
+			resp.Close()
+		}
+		So(received, ShouldResemble, map[string]string{"SellerA": "TokenA", "SellerB": "TokenB"})
+	})
+
+	Convey("Reuse the client of the seller and marketplace", t, func() {
+		clientA := client("SellerA")
+		So(clientA.MarketPlaceId, ShouldEqual, "ATVPDKIKX0DER")
+		So(clientA.Region, ShouldEqual, "US")
+
+		sameA, err := pool.Client("SellerA", "amazon.com", "2013-09-01", "Orders")
+		So(err, ShouldBeNil)
+		So(sameA, ShouldPointTo, clientA)
+
+		caA, err := pool.Client("SellerA", "CA", "2013-09-01", "Orders")
+		So(err, ShouldBeNil)
+		So(caA, ShouldNotPointTo, clientA)
+		So(caA.MarketPlaceId, ShouldEqual, "A2EUQ1WTGCTBG2")
+
+		productsA, err := pool.Client("SellerA", "US", "2011-10-01", "Products")
+		So(err, ShouldBeNil)
+		So(productsA, ShouldNotPointTo, clientA)
+	})
+
+	Convey("Hand out the clients of the API packages", t, func() {
+		for _, api := range []struct{ name, version string }{
+			{"Orders", "2013-09-01"}, {"Products", "2011-10-01"},
+			{"Reports", "2009-01-01"}, {"Feeds", "2009-01-01"},
+		} {
+			c, err := pool.Client("SellerA", "UK", api.version, api.name)
+			So(err, ShouldBeNil)
+			So(c.MarketPlaceId, ShouldEqual, "A1F83G8C2ARO7P")
+			So(c.Path(), ShouldEqual, "/"+api.name+"/"+api.version)
+
+			resp, err := c.SendRequest(Parameters{"Action": "GetServiceStatus"})
+			So(err, ShouldBeNil)
+			So(resp.Error, ShouldBeNil)
+			resp.Close()
+		}
+	})
+
+	Convey("Reject the unknown marketplace", t, func() {
+		_, err := pool.Client("SellerA", "Unknown", "2013-09-01", "Orders")
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "Invalid marketplace: Unknown")
+	})
+
+	Convey("Use the latest authorization", t, func() {
+		clientA := client("SellerA")
+		pool.Authorize("SellerA", "NewTokenA")
+		defer pool.Authorize("SellerA", "TokenA")
+
+		resp, err := clientA.SendRequest(Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		resp.Close()
+		So(received["SellerA"], ShouldEqual, "NewTokenA")
+	})
+
+	Convey("Reject the seller not authorized", t, func() {
+		_, err := pool.Client("SellerC", "ATVPDKIKX0DER", "2013-09-01", "Orders")
+		So(errors.Is(err, ErrSellerNotAuthorized), ShouldBeTrue)
+
+		Convey("Or revoked", func() {
+			pool.Authorize("SellerC", "TokenC")
+			clientC := client("SellerC")
+			pool.Revoke("SellerC")
+
+			_, err := clientC.SendRequest(Parameters{"Action": "GetOrder"})
+			So(errors.Is(err, ErrSellerNotAuthorized), ShouldBeTrue)
+			So(pool.Sellers(), ShouldResemble, []string{"SellerA", "SellerB"})
+
+			pool.Authorize("SellerC", "TokenC")
+			defer pool.Revoke("SellerC")
+			So(client("SellerC"), ShouldNotPointTo, clientC)
+		})
+	})
+
+	Convey("Throttle the sellers separately", t, func() {
+		// The throttler is set before handing out the clients.
+		pool = newPool()
+		pool.Throttler.SetQuota("Orders", "GetOrder", RequestQuota{MaxRequests: 1, RestoreRate: time.Hour})
+		clientA, clientB := client("SellerA"), client("SellerB")
+
+		resp, err := clientA.SendRequest(Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		resp.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = clientA.SendRequestContext(ctx, Parameters{"Action": "GetOrder"})
+		So(err, ShouldEqual, context.DeadlineExceeded)
+
+		resp, err = clientB.SendRequest(Parameters{"Action": "GetOrder"})
+		So(err, ShouldBeNil)
+		resp.Close()
+	})
+}
@@ -0,0 +1,347 @@
+package products
+
+import "github.com/svvu/gomws/mws"
+
+// Result status of each ASIN, SKU or Id in the request.
+const (
+	StatusSuccess         = "Success"
+	StatusClientError     = "ClientError"
+	StatusServerError     = "ServerError"
+	StatusNoBuyableOffers = "NoBuyableOffers"
+)
+
+// Money is a currency type and amount, see mws.Money.
+type Money = mws.Money
+
+// DecimalWithUnits is a decimal value with the units, ex: 2.00 inches.
+type DecimalWithUnits struct {
+	Units string  `xml:"Units,attr"`
+	Value float64 `xml:",chardata"`
+}
+
+// MarketplaceASIN the ASIN of the product in the marketplace.
+type MarketplaceASIN struct {
+	MarketplaceId string `xml:"MarketplaceId"`
+	ASIN          string `xml:"ASIN"`
+}
+
+// SKUIdentifier the seller SKU of the product in the marketplace.
+type SKUIdentifier struct {
+	MarketplaceId string `xml:"MarketplaceId"`
+	SellerId      string `xml:"SellerId"`
+	SellerSKU     string `xml:"SellerSKU"`
+}
+
+// Identifiers of the product.
+type Identifiers struct {
+	MarketplaceASIN MarketplaceASIN `xml:"MarketplaceASIN"`
+	SKUIdentifier   *SKUIdentifier  `xml:"SKUIdentifier"`
+}
+
+// Dimensions of the item or package.
+type Dimensions struct {
+	Height *DecimalWithUnits `xml:"Height"`
+	Length *DecimalWithUnits `xml:"Length"`
+	Width  *DecimalWithUnits `xml:"Width"`
+	Weight *DecimalWithUnits `xml:"Weight"`
+}
+
+// Image of the product.
+type Image struct {
+	URL    string           `xml:"URL"`
+	Height DecimalWithUnits `xml:"Height"`
+	Width  DecimalWithUnits `xml:"Width"`
+}
+
+// Creator of the product, ex: illustrator of the book.
+type Creator struct {
+	Role string `xml:"Role,attr"`
+	Name string `xml:",chardata"`
+}
+
+// Language of the product.
+type Language struct {
+	Name string `xml:"Name"`
+	Type string `xml:"Type"`
+}
+
+// ItemAttributes the attributes of the product.
+// Only the common attributes are decoded.
+// http://docs.developer.amazonservices.com/en_US/products/Products_Datatypes.html#ItemAttributes
+type ItemAttributes struct {
+	Lang              string      `xml:"lang,attr"`
+	Author            []string    `xml:"Author"`
+	Binding           string      `xml:"Binding"`
+	Brand             string      `xml:"Brand"`
+	Color             string      `xml:"Color"`
+	Creator           []Creator   `xml:"Creator"`
+	Department        string      `xml:"Department"`
+	Edition           string      `xml:"Edition"`
+	Feature           []string    `xml:"Feature"`
+	IsAdultProduct    bool        `xml:"IsAdultProduct"`
+	IsAutographed     bool        `xml:"IsAutographed"`
+	IsMemorabilia     bool        `xml:"IsMemorabilia"`
+	ItemDimensions    *Dimensions `xml:"ItemDimensions"`
+	Label             string      `xml:"Label"`
+	Languages         []Language  `xml:"Languages>Language"`
+	ListPrice         *Money      `xml:"ListPrice"`
+	Manufacturer      string      `xml:"Manufacturer"`
+	Model             string      `xml:"Model"`
+	NumberOfItems     int         `xml:"NumberOfItems"`
+	NumberOfPages     int         `xml:"NumberOfPages"`
+	PackageDimensions *Dimensions `xml:"PackageDimensions"`
+	PackageQuantity   int         `xml:"PackageQuantity"`
+	PartNumber        string      `xml:"PartNumber"`
+	ProductGroup      string      `xml:"ProductGroup"`
+	ProductTypeName   string      `xml:"ProductTypeName"`
+	PublicationDate   string      `xml:"PublicationDate"`
+	Publisher         string      `xml:"Publisher"`
+	ReleaseDate       string      `xml:"ReleaseDate"`
+	Size              string      `xml:"Size"`
+	SmallImage        *Image      `xml:"SmallImage"`
+	Studio            string      `xml:"Studio"`
+	Title             string      `xml:"Title"`
+}
+
+// AttributeSets the attribute sets of the product.
+type AttributeSets struct {
+	ItemAttributes []ItemAttributes `xml:"ItemAttributes"`
+}
+
+// Variation is a parent or child product of the product.
+type Variation struct {
+	Identifiers Identifiers `xml:"Identifiers"`
+	Color       string      `xml:"Color"`
+	Size        string      `xml:"Size"`
+}
+
+// Relationships the variation parents and children of the product.
+type Relationships struct {
+	VariationParent []Variation `xml:"VariationParent"`
+	VariationChild  []Variation `xml:"VariationChild"`
+}
+
+// SalesRank the sales rank of the product in the category.
+type SalesRank struct {
+	ProductCategoryId string `xml:"ProductCategoryId"`
+	Rank              int    `xml:"Rank"`
+}
+
+// Price is the price of an offer.
+type Price struct {
+	LandedPrice  *Money `xml:"LandedPrice"`
+	ListingPrice Money  `xml:"ListingPrice"`
+	Shipping     *Money `xml:"Shipping"`
+}
+
+// CompetitivePrice the competitive price of the product.
+type CompetitivePrice struct {
+	BelongsToRequester bool   `xml:"belongsToRequester,attr"`
+	Condition          string `xml:"condition,attr"`
+	Subcondition       string `xml:"subcondition,attr"`
+	CompetitivePriceId string `xml:"CompetitivePriceId"`
+	Price              Price  `xml:"Price"`
+}
+
+// OfferListingCount the number of offer listings with the condition.
+type OfferListingCount struct {
+	Condition string `xml:"condition,attr"`
+	Count     int    `xml:",chardata"`
+}
+
+// CompetitivePricing the competitive pricing of the product.
+type CompetitivePricing struct {
+	CompetitivePrices     []CompetitivePrice  `xml:"CompetitivePrices>CompetitivePrice"`
+	NumberOfOfferListings []OfferListingCount `xml:"NumberOfOfferListings>OfferListingCount"`
+	TradeInValue          *Money              `xml:"TradeInValue"`
+}
+
+// Qualifiers of the lowest offer listing.
+type Qualifiers struct {
+	ItemCondition                string `xml:"ItemCondition"`
+	ItemSubcondition             string `xml:"ItemSubcondition"`
+	FulfillmentChannel           string `xml:"FulfillmentChannel"`
+	ShipsDomestically            bool   `xml:"ShipsDomestically"`
+	ShippingTime                 string `xml:"ShippingTime>Max"`
+	SellerPositiveFeedbackRating string `xml:"SellerPositiveFeedbackRating"`
+}
+
+// LowestOfferListing the lowest price offer listing with the qualifiers.
+type LowestOfferListing struct {
+	Qualifiers                      Qualifiers `xml:"Qualifiers"`
+	NumberOfOfferListingsConsidered int        `xml:"NumberOfOfferListingsConsidered"`
+	SellerFeedbackCount             int        `xml:"SellerFeedbackCount"`
+	Price                           Price      `xml:"Price"`
+	MultipleOffersAtLowestPrice     bool       `xml:"MultipleOffersAtLowestPrice"`
+}
+
+// MyPriceOffer the seller's own offer of the product.
+type MyPriceOffer struct {
+	BuyingPrice        Price  `xml:"BuyingPrice"`
+	RegularPrice       Money  `xml:"RegularPrice"`
+	FulfillmentChannel string `xml:"FulfillmentChannel"`
+	ItemCondition      string `xml:"ItemCondition"`
+	ItemSubCondition   string `xml:"ItemSubCondition"`
+	SellerId           string `xml:"SellerId"`
+	SellerSKU          string `xml:"SellerSKU"`
+}
+
+// Product information, different operations return different parts of it.
+// http://docs.developer.amazonservices.com/en_US/products/Products_Datatypes.html#Product
+type Product struct {
+	Identifiers         Identifiers          `xml:"Identifiers"`
+	AttributeSets       AttributeSets        `xml:"AttributeSets"`
+	Relationships       Relationships        `xml:"Relationships"`
+	CompetitivePricing  *CompetitivePricing  `xml:"CompetitivePricing"`
+	SalesRankings       []SalesRank          `xml:"SalesRankings>SalesRank"`
+	LowestOfferListings []LowestOfferListing `xml:"LowestOfferListings>LowestOfferListing"`
+	Offers              []MyPriceOffer       `xml:"Offers>Offer"`
+}
+
+// ProductResult is the result for one ASIN, SKU or Id in the request.
+// If the status is not Success, the Error contains the reason.
+type ProductResult struct {
+	Status                     string     `xml:"status,attr"`
+	ASIN                       string     `xml:"ASIN,attr"`
+	SellerSKU                  string     `xml:"SellerSKU,attr"`
+	Id                         string     `xml:"Id,attr"`
+	IdType                     string     `xml:"IdType,attr"`
+	AllOfferListingsConsidered bool       `xml:"AllOfferListingsConsidered"`
+	Product                    *Product   `xml:"Product"`
+	Products                   []Product  `xml:"Products>Product"`
+	Error                      *mws.Error `xml:"Error"`
+}
+
+// Success check whether or not the result is success.
+func (pr ProductResult) Success() bool {
+	return pr.Status == StatusSuccess
+}
+
+// ProductResults the results for all the ASINs, SKUs or Ids in the request.
+type ProductResults []ProductResult
+
+// Succeeded return the results which are success.
+func (prs ProductResults) Succeeded() ProductResults {
+	results := ProductResults{}
+	for _, pr := range prs {
+		if pr.Success() {
+			results = append(results, pr)
+		}
+	}
+	return results
+}
+
+// Failed return the results which are not success.
+func (prs ProductResults) Failed() ProductResults {
+	results := ProductResults{}
+	for _, pr := range prs {
+		if !pr.Success() {
+			results = append(results, pr)
+		}
+	}
+	return results
+}
+
+// OfferIdentifier identify the product of the lowest priced offers.
+type OfferIdentifier struct {
+	MarketplaceId     string        `xml:"MarketplaceId"`
+	SellerSKU         string        `xml:"SellerSKU"`
+	ASIN              string        `xml:"ASIN"`
+	ItemCondition     string        `xml:"ItemCondition"`
+	TimeOfOfferChange mws.Timestamp `xml:"TimeOfOfferChange"`
+}
+
+// OfferCount the number of offers with the condition and fulfillment channel.
+type OfferCount struct {
+	Condition          string `xml:"condition,attr"`
+	FulfillmentChannel string `xml:"fulfillmentChannel,attr"`
+	Count              int    `xml:",chardata"`
+}
+
+// LowestPrice the lowest price with the condition and fulfillment channel.
+type LowestPrice struct {
+	Condition          string `xml:"condition,attr"`
+	FulfillmentChannel string `xml:"fulfillmentChannel,attr"`
+	LandedPrice        *Money `xml:"LandedPrice"`
+	ListingPrice       Money  `xml:"ListingPrice"`
+	Shipping           Money  `xml:"Shipping"`
+}
+
+// BuyBoxPrice the buy box price with the condition.
+type BuyBoxPrice struct {
+	Condition    string `xml:"condition,attr"`
+	LandedPrice  Money  `xml:"LandedPrice"`
+	ListingPrice Money  `xml:"ListingPrice"`
+	Shipping     Money  `xml:"Shipping"`
+}
+
+// Summary of the lowest priced offers.
+type Summary struct {
+	TotalOfferCount                 int           `xml:"TotalOfferCount"`
+	NumberOfOffers                  []OfferCount  `xml:"NumberOfOffers>OfferCount"`
+	LowestPrices                    []LowestPrice `xml:"LowestPrices>LowestPrice"`
+	BuyBoxPrices                    []BuyBoxPrice `xml:"BuyBoxPrices>BuyBoxPrice"`
+	ListPrice                       *Money        `xml:"ListPrice"`
+	SuggestedLowerPricePlusShipping *Money        `xml:"SuggestedLowerPricePlusShipping"`
+	BuyBoxEligibleOffers            []OfferCount  `xml:"BuyBoxEligibleOffers>OfferCount"`
+	OffersAvailableTime             mws.Timestamp `xml:"OffersAvailableTime"`
+}
+
+// SellerFeedbackRating the feedback rating of the seller.
+type SellerFeedbackRating struct {
+	SellerPositiveFeedbackRating float64 `xml:"SellerPositiveFeedbackRating"`
+	FeedbackCount                int     `xml:"FeedbackCount"`
+}
+
+// ShippingTime the time range to ship the offer.
+type ShippingTime struct {
+	MinimumHours     int    `xml:"minimumHours,attr"`
+	MaximumHours     int    `xml:"maximumHours,attr"`
+	AvailabilityType string `xml:"availabilityType,attr"`
+}
+
+// Offer is one of the lowest priced offers.
+type Offer struct {
+	MyOffer              bool                  `xml:"MyOffer"`
+	SubCondition         string                `xml:"SubCondition"`
+	SellerFeedbackRating *SellerFeedbackRating `xml:"SellerFeedbackRating"`
+	ShippingTime         ShippingTime          `xml:"ShippingTime"`
+	ListingPrice         Money                 `xml:"ListingPrice"`
+	Shipping             Money                 `xml:"Shipping"`
+	ShipsFromCountry     string                `xml:"ShipsFrom>Country"`
+	IsFulfilledByAmazon  bool                  `xml:"IsFulfilledByAmazon"`
+	IsBuyBoxWinner       bool                  `xml:"IsBuyBoxWinner"`
+	IsFeaturedMerchant   bool                  `xml:"IsFeaturedMerchant"`
+}
+
+// LowestPricedOffersResult is the result of GetLowestPricedOffersForSKU and
+// GetLowestPricedOffersForASIN.
+type LowestPricedOffersResult struct {
+	Status        string          `xml:"status,attr"`
+	MarketplaceID string          `xml:"MarketplaceID,attr"`
+	SKU           string          `xml:"SKU,attr"`
+	ASIN          string          `xml:"ASIN,attr"`
+	ItemCondition string          `xml:"ItemCondition,attr"`
+	Identifier    OfferIdentifier `xml:"Identifier"`
+	Summary       Summary         `xml:"Summary"`
+	Offers        []Offer         `xml:"Offers>Offer"`
+	Error         *mws.Error      `xml:"Error"`
+}
+
+// Category is a product category with its parent category.
+type Category struct {
+	ProductCategoryId   string    `xml:"ProductCategoryId"`
+	ProductCategoryName string    `xml:"ProductCategoryName"`
+	Parent              *Category `xml:"Parent"`
+}
+
+// ProductCategoriesResult is the result of GetProductCategoriesForSKU and
+// GetProductCategoriesForASIN.
+type ProductCategoriesResult struct {
+	Self []Category `xml:"Self"`
+}
+
+// ListMatchingProductsResult is the result of ListMatchingProducts.
+type ListMatchingProductsResult struct {
+	Products []Product `xml:"Products>Product"`
+}
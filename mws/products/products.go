@@ -3,6 +3,8 @@ package products
 // Reference http://docs.developer.amazonservices.com/en_US/products/Products_Overview.html
 
 import (
+	"context"
+
 	"github.com/svvu/gomws/mws"
 )
 
@@ -22,6 +24,27 @@ func NewClient(config mws.Config) (*Products, error) {
 	return prodcuts, nil
 }
 
+// NewPoolClient return the product client for the seller in the marketplace from the pool.
+func NewPoolClient(pool *mws.Pool, sellerID, marketplace string) (*Products, error) {
+	products := new(Products)
+	base, err := pool.Client(sellerID, marketplace, products.Version(), products.Name())
+	if err != nil {
+		return nil, err
+	}
+	products.Client = base
+	return products, nil
+}
+
+// ForMarketplace return a copy of the client send the requests for the
+// 	marketplace, which must be one of the marketplaces set in the config.
+func (p Products) ForMarketplace(marketplaceID string) (*Products, error) {
+	base, err := p.Client.ForMarketplace(marketplaceID)
+	if err != nil {
+		return nil, err
+	}
+	return &Products{base}, nil
+}
+
 // Version return the current version of api
 func (p Products) Version() string {
 	return "2011-10-01"
@@ -35,10 +58,15 @@ func (p Products) Name() string {
 // GetServiceStatus Returns the operational status of the Products API section.
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetServiceStatus.html
 func (p Products) GetServiceStatus() (*mws.Response, error) {
+	return p.GetServiceStatusContext(context.Background())
+}
+
+// GetServiceStatusContext is the context version of GetServiceStatus.
+func (p Products) GetServiceStatusContext(ctx context.Context) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action": "GetServiceStatus",
 	}
-	return p.SendRequest(params)
+	return p.SendRequestContext(ctx, params)
 }
 
 // ListMatchingProducts Returns a list of products and their attributes, based on a search query.
@@ -46,6 +74,11 @@ func (p Products) GetServiceStatus() (*mws.Response, error) {
 // 	QueryContextId - string
 // http://docs.developer.amazonservices.com/en_US/products/Products_ListMatchingProducts.html
 func (p Products) ListMatchingProducts(query string, optional ...mws.Parameters) (*mws.Response, error) {
+	return p.ListMatchingProductsContext(context.Background(), query, optional...)
+}
+
+// ListMatchingProductsContext is the context version of ListMatchingProducts.
+func (p Products) ListMatchingProductsContext(ctx context.Context, query string, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{"QueryContextId"}, optional)
 	params := mws.Parameters{
 		"Action":        "ListMatchingProducts",
@@ -53,12 +86,17 @@ func (p Products) ListMatchingProducts(query string, optional ...mws.Parameters)
 		"MarketplaceId": p.MarketPlaceId,
 	}.Merge(op)
 
-	return p.SendRequest(params)
+	return p.SendRequestContext(ctx, params)
 }
 
 // GetMatchingProduct Returns a list of products and their attributes, based on a list of ASIN values.
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetMatchingProduct.html
 func (p Products) GetMatchingProduct(asinList []string) (*mws.Response, error) {
+	return p.GetMatchingProductContext(context.Background(), asinList)
+}
+
+// GetMatchingProductContext is the context version of GetMatchingProduct.
+func (p Products) GetMatchingProductContext(ctx context.Context, asinList []string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":        "GetMatchingProduct",
 		"ASINList":      asinList,
@@ -66,12 +104,17 @@ func (p Products) GetMatchingProduct(asinList []string) (*mws.Response, error) {
 	}
 	structuredParams := params.StructureKeys("ASINList", "ASIN")
 
-	return p.SendRequest(structuredParams)
+	return p.SendRequestContext(ctx, structuredParams)
 }
 
 // GetMatchingProductForId Returns a list of products and their attributes, based on a list of ASIN, GCID, SellerSKU, UPC, EAN, ISBN, and JAN values.
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetMatchingProductForId.html
 func (p Products) GetMatchingProductForId(idType string, idList []string) (*mws.Response, error) {
+	return p.GetMatchingProductForIdContext(context.Background(), idType, idList)
+}
+
+// GetMatchingProductForIdContext is the context version of GetMatchingProductForId.
+func (p Products) GetMatchingProductForIdContext(ctx context.Context, idType string, idList []string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":        "GetMatchingProductForId",
 		"IdType":        idType,
@@ -80,12 +123,17 @@ func (p Products) GetMatchingProductForId(idType string, idList []string) (*mws.
 	}
 	structuredParams := params.StructureKeys("IdList", "Id")
 
-	return p.SendRequest(structuredParams)
+	return p.SendRequestContext(ctx, structuredParams)
 }
 
 // GetCompetitivePricingForSKU Returns the current competitive price of a product, based on SellerSKU.
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetCompetitivePricingForSKU.html
 func (p Products) GetCompetitivePricingForSKU(sellerSKUList []string) (*mws.Response, error) {
+	return p.GetCompetitivePricingForSKUContext(context.Background(), sellerSKUList)
+}
+
+// GetCompetitivePricingForSKUContext is the context version of GetCompetitivePricingForSKU.
+func (p Products) GetCompetitivePricingForSKUContext(ctx context.Context, sellerSKUList []string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":        "GetCompetitivePricingForSKU",
 		"SellerSKUList": sellerSKUList,
@@ -93,12 +141,17 @@ func (p Products) GetCompetitivePricingForSKU(sellerSKUList []string) (*mws.Resp
 	}
 	structuredParams := params.StructureKeys("SellerSKUList", "SellerSKU")
 
-	return p.SendRequest(structuredParams)
+	return p.SendRequestContext(ctx, structuredParams)
 }
 
 // GetCompetitivePricingForASIN Returns the current competitive price of a product, based on ASIN.
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetCompetitivePricingForASIN.html
 func (p Products) GetCompetitivePricingForASIN(asinList []string) (*mws.Response, error) {
+	return p.GetCompetitivePricingForASINContext(context.Background(), asinList)
+}
+
+// GetCompetitivePricingForASINContext is the context version of GetCompetitivePricingForASIN.
+func (p Products) GetCompetitivePricingForASINContext(ctx context.Context, asinList []string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":        "GetCompetitivePricingForASIN",
 		"ASINList":      asinList,
@@ -106,7 +159,7 @@ func (p Products) GetCompetitivePricingForASIN(asinList []string) (*mws.Response
 	}
 	structuredParams := params.StructureKeys("ASINList", "ASIN")
 
-	return p.SendRequest(structuredParams)
+	return p.SendRequestContext(ctx, structuredParams)
 }
 
 // GetLowestOfferListingsForSKU Returns pricing information for the lowest-price active offer listings for up to 20 products, based on SellerSKU.
@@ -115,6 +168,11 @@ func (p Products) GetCompetitivePricingForASIN(asinList []string) (*mws.Response
 // 	ExcludeMe - bool
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetLowestOfferListingsForSKU.html
 func (p Products) GetLowestOfferListingsForSKU(sellerSKUList []string, optional ...mws.Parameters) (*mws.Response, error) {
+	return p.GetLowestOfferListingsForSKUContext(context.Background(), sellerSKUList, optional...)
+}
+
+// GetLowestOfferListingsForSKUContext is the context version of GetLowestOfferListingsForSKU.
+func (p Products) GetLowestOfferListingsForSKUContext(ctx context.Context, sellerSKUList []string, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{"ItemCondition", "ExcludeMe"}, optional)
 	params := mws.Parameters{
 		"Action":        "GetLowestOfferListingsForSKU",
@@ -123,7 +181,7 @@ func (p Products) GetLowestOfferListingsForSKU(sellerSKUList []string, optional
 	}.Merge(op)
 	structuredParams := params.StructureKeys("SellerSKUList", "SellerSKU")
 
-	return p.SendRequest(structuredParams)
+	return p.SendRequestContext(ctx, structuredParams)
 }
 
 // GetLowestOfferListingsForASIN Returns pricing information for the lowest-price active offer listings for up to 20 products, based on ASIN.
@@ -132,6 +190,11 @@ func (p Products) GetLowestOfferListingsForSKU(sellerSKUList []string, optional
 // 	ExcludeMe - bool
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetLowestOfferListingsForASIN.html
 func (p Products) GetLowestOfferListingsForASIN(asinList []string, optional ...mws.Parameters) (*mws.Response, error) {
+	return p.GetLowestOfferListingsForASINContext(context.Background(), asinList, optional...)
+}
+
+// GetLowestOfferListingsForASINContext is the context version of GetLowestOfferListingsForASIN.
+func (p Products) GetLowestOfferListingsForASINContext(ctx context.Context, asinList []string, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{"ItemCondition", "ExcludeMe"}, optional)
 	params := mws.Parameters{
 		"Action":        "GetLowestOfferListingsForASIN",
@@ -140,12 +203,17 @@ func (p Products) GetLowestOfferListingsForASIN(asinList []string, optional ...m
 	}.Merge(op)
 	structuredParams := params.StructureKeys("ASINList", "ASIN")
 
-	return p.SendRequest(structuredParams)
+	return p.SendRequestContext(ctx, structuredParams)
 }
 
 // GetLowestPricedOffersForSKU Returns lowest priced offers for a single product, based on SellerSKU.
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetLowestPricedOffersForSKU.html
 func (p Products) GetLowestPricedOffersForSKU(sellerSKU, itemCondition string) (*mws.Response, error) {
+	return p.GetLowestPricedOffersForSKUContext(context.Background(), sellerSKU, itemCondition)
+}
+
+// GetLowestPricedOffersForSKUContext is the context version of GetLowestPricedOffersForSKU.
+func (p Products) GetLowestPricedOffersForSKUContext(ctx context.Context, sellerSKU, itemCondition string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":        "GetLowestPricedOffersForSKU",
 		"SellerSKU":     sellerSKU,
@@ -153,12 +221,17 @@ func (p Products) GetLowestPricedOffersForSKU(sellerSKU, itemCondition string) (
 		"MarketplaceId": p.MarketPlaceId,
 	}
 
-	return p.SendRequest(params)
+	return p.SendRequestContext(ctx, params)
 }
 
 // GetLowestPricedOffersForASIN Returns lowest priced offers for a single product, based on ASIN.
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetLowestPricedOffersForASIN.html
 func (p Products) GetLowestPricedOffersForASIN(asin, itemCondition string) (*mws.Response, error) {
+	return p.GetLowestPricedOffersForASINContext(context.Background(), asin, itemCondition)
+}
+
+// GetLowestPricedOffersForASINContext is the context version of GetLowestPricedOffersForASIN.
+func (p Products) GetLowestPricedOffersForASINContext(ctx context.Context, asin, itemCondition string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":        "GetLowestPricedOffersForASIN",
 		"ASIN":          asin,
@@ -166,12 +239,17 @@ func (p Products) GetLowestPricedOffersForASIN(asin, itemCondition string) (*mws
 		"MarketplaceId": p.MarketPlaceId,
 	}
 
-	return p.SendRequest(params)
+	return p.SendRequestContext(ctx, params)
 }
 
 // GetMyPriceForSKU Returns pricing information for your own offer listings, based on SellerSKU.
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetMyPriceForSKU.html
 func (p Products) GetMyPriceForSKU(sellerSKUList []string, optional ...mws.Parameters) (*mws.Response, error) {
+	return p.GetMyPriceForSKUContext(context.Background(), sellerSKUList, optional...)
+}
+
+// GetMyPriceForSKUContext is the context version of GetMyPriceForSKU.
+func (p Products) GetMyPriceForSKUContext(ctx context.Context, sellerSKUList []string, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{"ItemCondition"}, optional)
 	params := mws.Parameters{
 		"Action":        "GetMyPriceForSKU",
@@ -180,12 +258,17 @@ func (p Products) GetMyPriceForSKU(sellerSKUList []string, optional ...mws.Param
 	}.Merge(op)
 	structuredParams := params.StructureKeys("SellerSKUList", "SellerSKU")
 
-	return p.SendRequest(structuredParams)
+	return p.SendRequestContext(ctx, structuredParams)
 }
 
 // GetMyPriceForASIN Returns pricing information for your own offer listings, based on ASIN.
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetMyPriceForASIN.html
 func (p Products) GetMyPriceForASIN(asinList []string, optional ...mws.Parameters) (*mws.Response, error) {
+	return p.GetMyPriceForASINContext(context.Background(), asinList, optional...)
+}
+
+// GetMyPriceForASINContext is the context version of GetMyPriceForASIN.
+func (p Products) GetMyPriceForASINContext(ctx context.Context, asinList []string, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{"ItemCondition"}, optional)
 	params := mws.Parameters{
 		"Action":        "GetMyPriceForASIN",
@@ -194,29 +277,39 @@ func (p Products) GetMyPriceForASIN(asinList []string, optional ...mws.Parameter
 	}.Merge(op)
 	structuredParams := params.StructureKeys("ASINList", "ASIN")
 
-	return p.SendRequest(structuredParams)
+	return p.SendRequestContext(ctx, structuredParams)
 }
 
 // GetProductCategoriesForSKU Returns the parent product categories that a product belongs to, based on SellerSKU.
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetProductCategoriesForSKU.html
 func (p Products) GetProductCategoriesForSKU(sellerSKU string) (*mws.Response, error) {
+	return p.GetProductCategoriesForSKUContext(context.Background(), sellerSKU)
+}
+
+// GetProductCategoriesForSKUContext is the context version of GetProductCategoriesForSKU.
+func (p Products) GetProductCategoriesForSKUContext(ctx context.Context, sellerSKU string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":        "GetProductCategoriesForSKU",
 		"SellerSKU":     sellerSKU,
 		"MarketplaceId": p.MarketPlaceId,
 	}
 
-	return p.SendRequest(params)
+	return p.SendRequestContext(ctx, params)
 }
 
 // GetProductCategoriesForASIN Returns the parent product categories that a product belongs to, based on ASIN.
 // http://docs.developer.amazonservices.com/en_US/products/Products_GetProductCategoriesForASIN.html
 func (p Products) GetProductCategoriesForASIN(asin string) (*mws.Response, error) {
+	return p.GetProductCategoriesForASINContext(context.Background(), asin)
+}
+
+// GetProductCategoriesForASINContext is the context version of GetProductCategoriesForASIN.
+func (p Products) GetProductCategoriesForASINContext(ctx context.Context, asin string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":        "GetProductCategoriesForASIN",
 		"ASIN":          asin,
 		"MarketplaceId": p.MarketPlaceId,
 	}
 
-	return p.SendRequest(params)
+	return p.SendRequestContext(ctx, params)
 }
@@ -0,0 +1,148 @@
+package products
+
+import (
+	"context"
+
+	"github.com/svvu/gomws/mws"
+)
+
+// decodeProductResults decode all the per item results in the response.
+// Items failed on Amazon side are returned with the error in the result,
+// 	use ProductResults.Failed to get them.
+func decodeProductResults(resp *mws.Response, err error, tag string) (ProductResults, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	results := ProductResults{}
+	err = resp.DecodeResults(tag, func() interface{} {
+		results = append(results, ProductResult{})
+		return &results[len(results)-1]
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// decodeResult decode the single result in the response.
+func decodeResult(resp *mws.Response, err error, tag string, v interface{}) error {
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	return resp.DecodeResult(tag, v)
+}
+
+// ListMatchingProductsResult call ListMatchingProducts and decode the
+// products in the response.
+func (p Products) ListMatchingProductsResult(ctx context.Context, query string, optional ...mws.Parameters) (*ListMatchingProductsResult, error) {
+	resp, err := p.ListMatchingProductsContext(ctx, query, optional...)
+	result := &ListMatchingProductsResult{}
+	if err := decodeResult(resp, err, "ListMatchingProductsResult", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetMatchingProductResult call GetMatchingProduct and decode the result
+// for each ASIN.
+func (p Products) GetMatchingProductResult(ctx context.Context, asinList []string) (ProductResults, error) {
+	resp, err := p.GetMatchingProductContext(ctx, asinList)
+	return decodeProductResults(resp, err, "GetMatchingProductResult")
+}
+
+// GetMatchingProductForIdResult call GetMatchingProductForId and decode the
+// result for each Id.
+func (p Products) GetMatchingProductForIdResult(ctx context.Context, idType string, idList []string) (ProductResults, error) {
+	resp, err := p.GetMatchingProductForIdContext(ctx, idType, idList)
+	return decodeProductResults(resp, err, "GetMatchingProductForIdResult")
+}
+
+// GetCompetitivePricingForSKUResult call GetCompetitivePricingForSKU and
+// decode the result for each SKU.
+func (p Products) GetCompetitivePricingForSKUResult(ctx context.Context, sellerSKUList []string) (ProductResults, error) {
+	resp, err := p.GetCompetitivePricingForSKUContext(ctx, sellerSKUList)
+	return decodeProductResults(resp, err, "GetCompetitivePricingForSKUResult")
+}
+
+// GetCompetitivePricingForASINResult call GetCompetitivePricingForASIN and
+// decode the result for each ASIN.
+func (p Products) GetCompetitivePricingForASINResult(ctx context.Context, asinList []string) (ProductResults, error) {
+	resp, err := p.GetCompetitivePricingForASINContext(ctx, asinList)
+	return decodeProductResults(resp, err, "GetCompetitivePricingForASINResult")
+}
+
+// GetLowestOfferListingsForSKUResult call GetLowestOfferListingsForSKU and
+// decode the result for each SKU.
+func (p Products) GetLowestOfferListingsForSKUResult(ctx context.Context, sellerSKUList []string, optional ...mws.Parameters) (ProductResults, error) {
+	resp, err := p.GetLowestOfferListingsForSKUContext(ctx, sellerSKUList, optional...)
+	return decodeProductResults(resp, err, "GetLowestOfferListingsForSKUResult")
+}
+
+// GetLowestOfferListingsForASINResult call GetLowestOfferListingsForASIN and
+// decode the result for each ASIN.
+func (p Products) GetLowestOfferListingsForASINResult(ctx context.Context, asinList []string, optional ...mws.Parameters) (ProductResults, error) {
+	resp, err := p.GetLowestOfferListingsForASINContext(ctx, asinList, optional...)
+	return decodeProductResults(resp, err, "GetLowestOfferListingsForASINResult")
+}
+
+// GetLowestPricedOffersForSKUResult call GetLowestPricedOffersForSKU and
+// decode the offers in the response.
+func (p Products) GetLowestPricedOffersForSKUResult(ctx context.Context, sellerSKU, itemCondition string) (*LowestPricedOffersResult, error) {
+	resp, err := p.GetLowestPricedOffersForSKUContext(ctx, sellerSKU, itemCondition)
+	result := &LowestPricedOffersResult{}
+	if err := decodeResult(resp, err, "GetLowestPricedOffersForSKUResult", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetLowestPricedOffersForASINResult call GetLowestPricedOffersForASIN and
+// decode the offers in the response.
+func (p Products) GetLowestPricedOffersForASINResult(ctx context.Context, asin, itemCondition string) (*LowestPricedOffersResult, error) {
+	resp, err := p.GetLowestPricedOffersForASINContext(ctx, asin, itemCondition)
+	result := &LowestPricedOffersResult{}
+	if err := decodeResult(resp, err, "GetLowestPricedOffersForASINResult", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetMyPriceForSKUResult call GetMyPriceForSKU and decode the result
+// for each SKU.
+func (p Products) GetMyPriceForSKUResult(ctx context.Context, sellerSKUList []string, optional ...mws.Parameters) (ProductResults, error) {
+	resp, err := p.GetMyPriceForSKUContext(ctx, sellerSKUList, optional...)
+	return decodeProductResults(resp, err, "GetMyPriceForSKUResult")
+}
+
+// GetMyPriceForASINResult call GetMyPriceForASIN and decode the result
+// for each ASIN.
+func (p Products) GetMyPriceForASINResult(ctx context.Context, asinList []string, optional ...mws.Parameters) (ProductResults, error) {
+	resp, err := p.GetMyPriceForASINContext(ctx, asinList, optional...)
+	return decodeProductResults(resp, err, "GetMyPriceForASINResult")
+}
+
+// GetProductCategoriesForSKUResult call GetProductCategoriesForSKU and
+// decode the categories in the response.
+func (p Products) GetProductCategoriesForSKUResult(ctx context.Context, sellerSKU string) (*ProductCategoriesResult, error) {
+	resp, err := p.GetProductCategoriesForSKUContext(ctx, sellerSKU)
+	result := &ProductCategoriesResult{}
+	if err := decodeResult(resp, err, "GetProductCategoriesForSKUResult", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetProductCategoriesForASINResult call GetProductCategoriesForASIN and
+// decode the categories in the response.
+func (p Products) GetProductCategoriesForASINResult(ctx context.Context, asin string) (*ProductCategoriesResult, error) {
+	resp, err := p.GetProductCategoriesForASINContext(ctx, asin)
+	result := &ProductCategoriesResult{}
+	if err := decodeResult(resp, err, "GetProductCategoriesForASINResult", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,239 @@
+package products
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+	"github.com/svvu/gomws/mws/mock"
+)
+
+func testClient(server *mock.Server) *Products {
+	client, _ := NewClient(mws.Config{
+		SellerId:  "SellerID",
+		AuthToken: "AuthToken",
+		Region:    "US",
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+	})
+	client.Host = server.Host()
+	client.Transport = mock.NoVerifyTransport()
+	return client
+}
+
+func exampleResponse(name string) *http.Response {
+	return exampleResponseWithStatus(200, name)
+}
+
+func exampleResponseWithStatus(status int, name string) *http.Response {
+	body, _ := ioutil.ReadFile("./exampleResponses/" + name + ".xml")
+	return mock.NewResponse(status, string(body))
+}
+
+func TestProducts_GetMatchingProductResult(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	client := testClient(server)
+
+	Convey("Decode GetMatchingProduct response", t, func() {
+		server.SetResponse(exampleResponse("GetMatchingProduct"))
+
+		results, err := client.GetMatchingProductResult(context.Background(), []string{"B002KT3XRQ"})
+
+		So(err, ShouldBeNil)
+		So(len(results), ShouldEqual, 1)
+		So(results[0].Success(), ShouldBeTrue)
+		So(results[0].ASIN, ShouldEqual, "B002KT3XRQ")
+
+		product := results[0].Product
+		So(product.Identifiers.MarketplaceASIN.ASIN, ShouldEqual, "B002KT3XRQ")
+		So(len(product.AttributeSets.ItemAttributes), ShouldEqual, 1)
+		attrs := product.AttributeSets.ItemAttributes[0]
+		So(attrs.Title, ShouldEqual, "Pearl iZUMi Men's Quest Cycling Short")
+		So(attrs.ListPrice.Amount, ShouldEqual, "50.00")
+		So(len(product.Relationships.VariationChild), ShouldEqual, 5)
+		So(product.Relationships.VariationChild[0].Identifiers.MarketplaceASIN.ASIN, ShouldEqual, "B002KT3XQC")
+		So(len(product.SalesRankings), ShouldEqual, 3)
+		So(product.SalesRankings[0].Rank, ShouldEqual, 159)
+	})
+
+	Convey("Decode GetMatchingProductForId response", t, func() {
+		server.SetResponse(exampleResponse("GetMatchingProductForId"))
+
+		results, err := client.GetMatchingProductForIdResult(context.Background(), "ISBN", []string{"9781933988665", "0439708184"})
+
+		So(err, ShouldBeNil)
+		So(len(results), ShouldEqual, 2)
+		So(results[0].Id, ShouldEqual, "9781933988665")
+		So(results[0].IdType, ShouldEqual, "ISBN")
+		So(len(results[0].Products), ShouldBeGreaterThan, 0)
+
+		attrs := results[0].Products[0].AttributeSets.ItemAttributes[0]
+		So(attrs.Lang, ShouldEqual, "en-US")
+		So(attrs.Author, ShouldResemble, []string{"Marmanis, Haralambos", "Babenko, Dmitry"})
+		So(attrs.ItemDimensions.Height.Units, ShouldEqual, "inches")
+		So(attrs.ItemDimensions.Height.Value, ShouldEqual, 9.17)
+		So(len(attrs.Languages), ShouldEqual, 3)
+		So(attrs.NumberOfPages, ShouldEqual, 368)
+	})
+}
+
+func TestProducts_GetCompetitivePricingResult(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	client := testClient(server)
+
+	Convey("Decode GetCompetitivePricingForASIN response", t, func() {
+		server.SetResponse(exampleResponse("GetCompetitivePricingForASIN"))
+
+		results, err := client.GetCompetitivePricingForASINResult(context.Background(), []string{"B002KT3XQM"})
+
+		So(err, ShouldBeNil)
+		So(len(results.Succeeded()), ShouldEqual, 1)
+		So(len(results.Failed()), ShouldEqual, 0)
+
+		pricing := results[0].Product.CompetitivePricing
+		So(len(pricing.CompetitivePrices), ShouldEqual, 1)
+		So(pricing.CompetitivePrices[0].CompetitivePriceId, ShouldEqual, "1")
+		So(pricing.CompetitivePrices[0].Price.LandedPrice.Amount, ShouldEqual, "34.27")
+		So(pricing.CompetitivePrices[0].Price.Shipping.Amount, ShouldEqual, "0.00")
+	})
+
+	Convey("Keep the item error in the result", t, func() {
+		server.SetResponse(exampleResponse("GetCompetitivePricingForASIN_ClientError"))
+
+		results, err := client.GetCompetitivePricingForASINResult(context.Background(), []string{"ABC"})
+
+		So(err, ShouldBeNil)
+		So(len(results.Succeeded()), ShouldEqual, 0)
+		failed := results.Failed()
+		So(len(failed), ShouldEqual, 1)
+		So(failed[0].Status, ShouldEqual, StatusClientError)
+		So(failed[0].ASIN, ShouldEqual, "ABC")
+		So(failed[0].Product, ShouldBeNil)
+		So(failed[0].Error.Code, ShouldEqual, "InvalidParameterValue")
+		So(failed[0].Error.Type, ShouldEqual, "Sender")
+	})
+}
+
+func TestProducts_GetLowestOfferListingsForSKUResult(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	client := testClient(server)
+
+	Convey("Decode GetLowestOfferListingsForSKU response", t, func() {
+		server.SetResponse(exampleResponse("GetLowestOfferListingsForSKU"))
+
+		results, err := client.GetLowestOfferListingsForSKUResult(context.Background(), []string{"SKU2468"})
+
+		So(err, ShouldBeNil)
+		So(results[0].SellerSKU, ShouldEqual, "SKU2468")
+		So(results[0].AllOfferListingsConsidered, ShouldBeFalse)
+
+		product := results[0].Product
+		So(product.Identifiers.SKUIdentifier.SellerSKU, ShouldEqual, "SKU2468")
+		So(len(product.LowestOfferListings), ShouldBeGreaterThan, 0)
+		listing := product.LowestOfferListings[0]
+		So(listing.Qualifiers.ItemCondition, ShouldEqual, "Used")
+		So(listing.Qualifiers.ShipsDomestically, ShouldBeTrue)
+		So(listing.Qualifiers.ShippingTime, ShouldEqual, "0-2 days")
+		So(listing.SellerFeedbackCount, ShouldEqual, 8900)
+		So(listing.Price.ListingPrice.Amount, ShouldEqual, "24.69")
+		So(listing.MultipleOffersAtLowestPrice, ShouldBeTrue)
+	})
+}
+
+func TestProducts_GetLowestPricedOffersForSKUResult(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	client := testClient(server)
+
+	Convey("Decode GetLowestPricedOffersForSKU response", t, func() {
+		server.SetResponse(exampleResponse("GetLowestPricedOffersForSKU"))
+
+		result, err := client.GetLowestPricedOffersForSKUResult(context.Background(), "GE Product", "New")
+
+		So(err, ShouldBeNil)
+		So(result.Status, ShouldEqual, StatusSuccess)
+		So(result.SKU, ShouldEqual, "GE Product")
+		So(result.Identifier.SellerSKU, ShouldEqual, "GE Product")
+
+		summary := result.Summary
+		So(summary.TotalOfferCount, ShouldEqual, 1)
+		So(summary.NumberOfOffers[0].FulfillmentChannel, ShouldEqual, "Amazon")
+		So(summary.NumberOfOffers[0].Count, ShouldEqual, 1)
+		So(summary.BuyBoxPrices[0].Condition, ShouldEqual, "New")
+		So(summary.BuyBoxPrices[0].LandedPrice.Amount, ShouldEqual, "32.99")
+		So(summary.ListPrice.Amount, ShouldEqual, "58.34")
+
+		So(len(result.Offers), ShouldEqual, 1)
+		offer := result.Offers[0]
+		So(offer.SellerFeedbackRating.FeedbackCount, ShouldEqual, 1)
+		So(offer.ShippingTime.AvailabilityType, ShouldEqual, "NOW")
+		So(offer.IsBuyBoxWinner, ShouldBeTrue)
+	})
+
+	Convey("Decode response without offers", t, func() {
+		server.SetResponse(exampleResponse("GetLowestPricedOffersForSKU_NoOffers"))
+
+		result, err := client.GetLowestPricedOffersForSKUResult(context.Background(), "0I-RIAS-6UA0", "New")
+
+		So(err, ShouldBeNil)
+		So(result.Status, ShouldEqual, StatusNoBuyableOffers)
+		So(result.Summary.TotalOfferCount, ShouldEqual, 0)
+		So(len(result.Offers), ShouldEqual, 0)
+	})
+
+	Convey("Return error when request not success", t, func() {
+		server.SetResponse(exampleResponseWithStatus(500, "GetLowestPricedOffersForSKU_ServerError"))
+
+		result, err := client.GetLowestPricedOffersForSKUResult(context.Background(), "24478624", "New")
+
+		So(result, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+		apiErr, ok := err.(*mws.APIError)
+		So(ok, ShouldBeTrue)
+		So(apiErr.StatusCode, ShouldEqual, 500)
+	})
+}
+
+func TestProducts_GetMyPriceForSKUResult(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	client := testClient(server)
+
+	Convey("Decode GetMyPriceForSKU response", t, func() {
+		server.SetResponse(exampleResponse("GetMyPriceForSKU"))
+
+		results, err := client.GetMyPriceForSKUResult(context.Background(), []string{"SKU2468"})
+
+		So(err, ShouldBeNil)
+		offers := results[0].Product.Offers
+		So(len(offers), ShouldEqual, 1)
+		So(offers[0].BuyingPrice.LandedPrice.Amount, ShouldEqual, "303.99")
+		So(offers[0].RegularPrice.Amount, ShouldEqual, "300.00")
+		So(offers[0].ItemSubCondition, ShouldEqual, "Acceptable")
+		So(offers[0].SellerSKU, ShouldEqual, "SKU2468")
+	})
+}
+
+func TestProducts_GetProductCategoriesForASINResult(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	client := testClient(server)
+
+	Convey("Decode GetProductCategoriesForASIN response", t, func() {
+		server.SetResponse(exampleResponse("GetProductCategoriesForASIN"))
+
+		result, err := client.GetProductCategoriesForASINResult(context.Background(), "B002KT3XQM")
+
+		So(err, ShouldBeNil)
+		So(len(result.Self), ShouldEqual, 1)
+		So(result.Self[0].ProductCategoryName, ShouldEqual, "Compression Shorts")
+		So(result.Self[0].Parent.ProductCategoryName, ShouldEqual, "Men")
+		So(result.Self[0].Parent.Parent.Parent.ProductCategoryName, ShouldEqual, "Bikes & Accessories")
+	})
+}
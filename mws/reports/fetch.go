@@ -0,0 +1,188 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/svvu/gomws/mws"
+)
+
+// DefaultPollInterval is the time between two polls of the report request
+// 	status, same as the restore rate of GetReportRequestList.
+const DefaultPollInterval = 45 * time.Second
+
+// Errors returned by Fetch when the report request finished without report.
+var (
+	ErrReportCancelled = errors.New("Report request cancelled")
+	ErrReportNoData    = errors.New("Report request done with no data")
+)
+
+// FetchOptions the options for Fetch.
+type FetchOptions struct {
+	// Optional parameters for RequestReport, ex: StartDate, EndDate.
+	Parameters mws.Parameters
+	// Time between two polls of the report request status.
+	// Default to DefaultPollInterval.
+	PollInterval time.Duration
+	// Progress is called with the latest info of the report request, once
+	// 	requested and after each poll.
+	Progress func(FetchProgress)
+}
+
+// FetchProgress the state of the report request during Fetch.
+type FetchProgress struct {
+	// Number of polls made to GetReportRequestList.
+	Polls int
+	// The latest info of the report request.
+	Info ReportRequestInfo
+}
+
+// FetchedReport is the report returned by Fetch.
+// The body is not read yet, the report can be streamed by Read, or write to
+// 	other places by WriteBodyTo and ExportTo.
+// The body is verified by the Content-MD5 header in all the ways.
+// Read decode the body to UTF-8 by the encoding of the marketplace, while
+// 	WriteBodyTo and ExportTo keep the body as it is.
+// Must be closed after use.
+type FetchedReport struct {
+	// Info of the finished report request, GeneratedReportId is the report id.
+	Info ReportRequestInfo
+
+	*mws.Response
+	body io.Reader
+}
+
+// Read read the report body decoded to UTF-8.
+// A *mws.ChecksumError is returned at the end if the body is not verified.
+func (fr *FetchedReport) Read(p []byte) (int, error) {
+	return fr.body.Read(p)
+}
+
+// Close drain and close the report body.
+func (fr *FetchedReport) Close() error {
+	fr.Response.Close()
+	return nil
+}
+
+// Fetch request the report and wait until it finished, then return the report.
+// The workflow is:
+// 	RequestReport -> poll GetReportRequestList until the request is done -> GetReport.
+// If the request is cancelled or done with no data, ErrReportCancelled or
+// 	ErrReportNoData will be returned.
+// The requests go through the client, so the retry policy and throttler of
+// 	the client are used. Polls throttled by MWS will be retried in next poll.
+func (r Reports) Fetch(ctx context.Context, reportType string, opts FetchOptions) (*FetchedReport, error) {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	progress := FetchProgress{}
+	report := func() {
+		if opts.Progress != nil {
+			opts.Progress(progress)
+		}
+	}
+
+	resp, err := r.RequestReportContext(ctx, reportType, opts.Parameters)
+	info, err := decodeReportRequestInfo(resp, err)
+	if err != nil {
+		return nil, err
+	}
+	progress.Info = info
+	report()
+
+	for !isFinished(progress.Info.ReportProcessingStatus) {
+		if err := mws.SleepContext(ctx, interval); err != nil {
+			return nil, err
+		}
+
+		resp, err := r.GetReportRequestListContext(ctx, mws.Parameters{
+			"ReportRequestIdList": []string{progress.Info.ReportRequestId},
+		})
+		info, err := decodeReportRequestInfo(resp, err)
+		progress.Polls++
+		if errors.Is(err, mws.ErrThrottled) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		progress.Info = info
+		report()
+	}
+
+	switch progress.Info.ReportProcessingStatus {
+	case StatusCancelled:
+		return nil, fmt.Errorf("%w: %v", ErrReportCancelled, progress.Info.ReportRequestId)
+	case StatusDoneNoData:
+		return nil, fmt.Errorf("%w: %v", ErrReportNoData, progress.Info.ReportRequestId)
+	}
+
+	reportID := progress.Info.GeneratedReportId
+	if reportID == "" {
+		reportID, err = r.findReportID(ctx, progress.Info.ReportRequestId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err = r.GetReportContext(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		resp.Close()
+		return nil, resp.Error
+	}
+
+	body, err := resp.DecodedBody(r.Region)
+	if err != nil {
+		resp.Close()
+		return nil, err
+	}
+
+	return &FetchedReport{Info: progress.Info, Response: resp, body: body}, nil
+}
+
+// findReportID find the id of the report generated by the report request.
+// Some report requests don't return the GeneratedReportId, the id is
+// 	looked up from GetReportList.
+func (r Reports) findReportID(ctx context.Context, reportRequestID string) (string, error) {
+	resp, err := r.GetReportListContext(ctx, mws.Parameters{
+		"ReportRequestIdList": []string{reportRequestID},
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Close()
+
+	info := ReportInfo{}
+	if err := resp.DecodeResult("ReportInfo", &info); err != nil {
+		return "", err
+	}
+	return info.ReportId, nil
+}
+
+// decodeReportRequestInfo decode the report request info in the response.
+func decodeReportRequestInfo(resp *mws.Response, err error) (ReportRequestInfo, error) {
+	info := ReportRequestInfo{}
+	if err != nil {
+		return info, err
+	}
+	defer resp.Close()
+
+	err = resp.DecodeResult("ReportRequestInfo", &info)
+	return info, err
+}
+
+// isFinished check whether or not the report request is finished.
+func isFinished(status string) bool {
+	switch status {
+	case StatusDone, StatusDoneNoData, StatusCancelled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+	"github.com/svvu/gomws/mws/mock"
+)
+
+func testClient(server *mock.Server) *Reports {
+	client, _ := NewClient(mws.Config{
+		SellerId:  "SellerID",
+		AuthToken: "AuthToken",
+		Region:    "US",
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+	})
+	client.Host = server.Host()
+	client.Transport = mock.NoVerifyTransport()
+	return client
+}
+
+func exampleBody(name string) string {
+	body, _ := ioutil.ReadFile("./exampleResponses/" + name)
+	return string(body)
+}
+
+// reportServer serve the report request workflow, the report request status
+// 	change from the statuses one by one on each poll.
+func reportServer(server *mock.Server, statuses ...string) *[]string {
+	actions := []string{}
+	server.SetResponseHandler(func(r *http.Request) *http.Response {
+		r.ParseForm()
+		action := r.PostForm.Get("Action")
+		actions = append(actions, action)
+
+		switch action {
+		case "RequestReport":
+			return mock.NewResponse(200, exampleBody("RequestReport.xml"))
+		case "GetReportRequestList":
+			status := statuses[0]
+			if len(statuses) > 1 {
+				statuses = statuses[1:]
+			}
+			if status == "throttled" {
+				return mock.NewResponse(503, "<ErrorResponse><Error><Code>RequestThrottled</Code></Error></ErrorResponse>")
+			}
+			body := strings.Replace(exampleBody("GetReportRequestList.xml"), "_DONE_", status, 1)
+			return mock.NewResponse(200, body)
+		case "GetReportList":
+			return mock.NewResponse(200, exampleBody("GetReportList.xml"))
+		case "GetReport":
+			resp := mock.NewResponse(200, exampleBody("GetReport.txt"))
+			resp.Header.Set("Content-MD5", mws.Body{Content: []byte(exampleBody("GetReport.txt"))}.MD5())
+			return resp
+		}
+		return mock.NewResponse(400, "")
+	})
+	return &actions
+}
+
+func TestReports_Fetch(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+	client := testClient(server)
+	opts := FetchOptions{PollInterval: time.Millisecond}
+
+	Convey("Fetch the report once the request is done", t, func() {
+		actions := reportServer(server, StatusInProgress, "throttled", StatusDone)
+		progresses := []FetchProgress{}
+		opts.Progress = func(p FetchProgress) { progresses = append(progresses, p) }
+
+		report, err := client.Fetch(context.Background(), "_GET_MERCHANT_LISTINGS_DATA_", opts)
+		So(err, ShouldBeNil)
+		defer report.Close()
+
+		So(report.Info.GeneratedReportId, ShouldEqual, "3538561173")
+		body, _ := ioutil.ReadAll(report)
+		So(string(body), ShouldEqual, exampleBody("GetReport.txt"))
+
+		So(*actions, ShouldResemble, []string{
+			"RequestReport", "GetReportRequestList", "GetReportRequestList",
+			"GetReportRequestList", "GetReport",
+		})
+		So(len(progresses), ShouldEqual, 3)
+		So(progresses[0].Info.ReportProcessingStatus, ShouldEqual, StatusSubmitted)
+		So(progresses[1].Info.ReportProcessingStatus, ShouldEqual, StatusInProgress)
+		So(progresses[2].Info.ReportProcessingStatus, ShouldEqual, StatusDone)
+		So(progresses[2].Polls, ShouldEqual, 3)
+	})
+
+	Convey("Find the report id when not generated in request info", t, func() {
+		server.SetResponseHandler(func(r *http.Request) *http.Response {
+			r.ParseForm()
+			switch r.PostForm.Get("Action") {
+			case "RequestReport":
+				return mock.NewResponse(200, exampleBody("RequestReport.xml"))
+			case "GetReportRequestList":
+				body := strings.Replace(exampleBody("GetReportRequestList.xml"),
+					"<GeneratedReportId>3538561173</GeneratedReportId>", "", 1)
+				return mock.NewResponse(200, body)
+			case "GetReportList":
+				return mock.NewResponse(200, exampleBody("GetReportList.xml"))
+			}
+			if r.PostForm.Get("ReportId") != "3538561173" {
+				return mock.NewResponse(400, "")
+			}
+			return mock.NewResponse(200, exampleBody("GetReport.txt"))
+		})
+
+		report, err := client.Fetch(context.Background(), "_GET_MERCHANT_LISTINGS_DATA_", opts)
+		So(err, ShouldBeNil)
+		defer report.Close()
+
+		body, _ := ioutil.ReadAll(report)
+		So(string(body), ShouldEqual, exampleBody("GetReport.txt"))
+	})
+
+	Convey("Return error when read the report not match the Content-MD5", t, func() {
+		server.SetResponseHandler(func(r *http.Request) *http.Response {
+			r.ParseForm()
+			switch r.PostForm.Get("Action") {
+			case "RequestReport":
+				return mock.NewResponse(200, exampleBody("RequestReport.xml"))
+			case "GetReportRequestList":
+				return mock.NewResponse(200, exampleBody("GetReportRequestList.xml"))
+			}
+			resp := mock.NewResponse(200, exampleBody("GetReport.txt"))
+			resp.Header.Set("Content-MD5", mws.Body{Content: []byte("truncated")}.MD5())
+			return resp
+		})
+
+		report, err := client.Fetch(context.Background(), "_GET_MERCHANT_LISTINGS_DATA_", opts)
+		So(err, ShouldBeNil)
+		defer report.Close()
+
+		_, err = ioutil.ReadAll(report)
+		So(err, ShouldHaveSameTypeAs, &mws.ChecksumError{})
+	})
+
+	Convey("Return error when the request done with no data", t, func() {
+		reportServer(server, StatusDoneNoData)
+
+		report, err := client.Fetch(context.Background(), "_GET_MERCHANT_LISTINGS_DATA_", opts)
+
+		So(report, ShouldBeNil)
+		So(errors.Is(err, ErrReportNoData), ShouldBeTrue)
+	})
+
+	Convey("Return error when the request cancelled", t, func() {
+		reportServer(server, StatusCancelled)
+
+		report, err := client.Fetch(context.Background(), "_GET_MERCHANT_LISTINGS_DATA_", opts)
+
+		So(report, ShouldBeNil)
+		So(errors.Is(err, ErrReportCancelled), ShouldBeTrue)
+	})
+
+	Convey("Stop polling when context is done", t, func() {
+		reportServer(server, StatusInProgress)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		report, err := client.Fetch(ctx, "_GET_MERCHANT_LISTINGS_DATA_", FetchOptions{PollInterval: time.Hour})
+
+		So(report, ShouldBeNil)
+		So(err, ShouldEqual, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,293 @@
+package reports
+
+import (
+	"bufio"
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RowReader read the rows of the flat file (tab-delimited) reports.
+// The first line of the report is the header, the columns of each row are
+// 	mapped to the struct fields by the tsv tag of the field.
+// Ex:
+// 	type Row struct {
+// 		SKU      string  `tsv:"seller-sku"`
+// 		Price    Decimal `tsv:"price"`
+// 		Quantity int     `tsv:"quantity"`
+// 	}
+// 	reader, err := NewRowReader(report)
+// 	for {
+// 		row := Row{}
+// 		err := reader.Read(&row)
+// 		if err == io.EOF {
+// 			break
+// 		}
+// 	}
+// Fields without the tsv tag and columns not in the struct are ignored.
+// Supported field types: string, bool, int, uint, float, Decimal and the
+// 	types implement encoding.TextUnmarshaler.
+// The fields are reset before each row, the empty columns are left zero.
+type RowReader struct {
+	// Parse the decimals with the decimal comma used by some marketplaces,
+	// 	ex: 12,34 in the DE settlement reports. The dots are the thousands
+	// 	separators then, ex: 1.234,56, misplaced dots are rejected, ex: 12.5.
+	// When not set, the decimals with comma are rejected, ex: 1,234.
+	DecimalComma bool
+
+	reader  *bufio.Reader
+	header  []string
+	columns map[string]int
+	line    int
+}
+
+// NewRowReader create a row reader, the header line is read immediately.
+func NewRowReader(r io.Reader) (*RowReader, error) {
+	rr := &RowReader{reader: bufio.NewReader(r)}
+
+	header, err := rr.readLine()
+	if err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("No header found in report")
+		}
+		return nil, err
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	rr.header = header
+	rr.columns = map[string]int{}
+	for i, column := range header {
+		rr.columns[strings.TrimSpace(column)] = i
+	}
+	return rr, nil
+}
+
+// Header return the column names in the header line.
+func (rr *RowReader) Header() []string {
+	return rr.header
+}
+
+// Line return the line number of the last read row, header is line 1.
+func (rr *RowReader) Line() int {
+	return rr.line
+}
+
+// ReadMap read the next row as a map of column name to value.
+// io.EOF is returned when no more rows.
+func (rr *RowReader) ReadMap() (map[string]string, error) {
+	values, err := rr.readRow()
+	if err != nil {
+		return nil, err
+	}
+
+	row := map[string]string{}
+	for column, i := range rr.columns {
+		if i < len(values) {
+			row[column] = values[i]
+		}
+	}
+	return row, nil
+}
+
+// Read read the next row into the struct pointer by the tsv tags.
+// io.EOF is returned when no more rows.
+func (rr *RowReader) Read(row interface{}) error {
+	v := reflect.ValueOf(row)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("Expect a struct pointer, got %T", row)
+	}
+
+	values, err := rr.readRow()
+	if err != nil {
+		return err
+	}
+
+	v = v.Elem()
+	v.Set(reflect.Zero(v.Type()))
+	for _, field := range tsvFields(v.Type()) {
+		i, ok := rr.columns[field.column]
+		if !ok || i >= len(values) {
+			continue
+		}
+		err := setField(v.Field(field.index), values[i], rr.DecimalComma)
+		if err != nil {
+			return fmt.Errorf("Line %v column %v: %v", rr.line, field.column, err)
+		}
+	}
+	return nil
+}
+
+// readRow read the next non empty line.
+func (rr *RowReader) readRow() ([]string, error) {
+	for {
+		values, err := rr.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if len(values) > 1 || values[0] != "" {
+			return values, nil
+		}
+	}
+}
+
+// readLine read the next line and split it by tab.
+func (rr *RowReader) readLine() ([]string, error) {
+	line, err := rr.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return nil, err
+	}
+	rr.line++
+
+	line = strings.TrimRight(line, "\r\n")
+	return strings.Split(line, "\t"), nil
+}
+
+// tsvField is a struct field mapped to a column.
+type tsvField struct {
+	index  int
+	column string
+}
+
+var tsvFieldsCache sync.Map
+
+// tsvFields return the fields with tsv tag of the struct type.
+func tsvFields(t reflect.Type) []tsvField {
+	if fields, ok := tsvFieldsCache.Load(t); ok {
+		return fields.([]tsvField)
+	}
+
+	fields := []tsvField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		column := f.Tag.Get("tsv")
+		if column == "" || column == "-" || f.PkgPath != "" {
+			continue
+		}
+		fields = append(fields, tsvField{index: i, column: column})
+	}
+
+	tsvFieldsCache.Store(t, fields)
+	return fields
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// setField set the field by the string value.
+// Empty value leave the field as zero value.
+func setField(field reflect.Value, value string, decimalComma bool) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	if field.Type() == decimalType || field.Kind() == reflect.Float32 || field.Kind() == reflect.Float64 {
+		var err error
+		if value, err = normalizeDecimal(value, decimalComma); err != nil {
+			return err
+		}
+	}
+
+	if field.CanAddr() && field.Addr().Type().Implements(textUnmarshalerType) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	switch field.Kind() {
+	default:
+		return fmt.Errorf("Unsupported type %v", field.Type())
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := parseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	}
+	return nil
+}
+
+// parseBool parse the bool, the y/n and yes/no used by some reports are
+// 	also accepted.
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// normalizeDecimal convert the decimal with the decimal comma to the dot
+// 	decimal, ex: "1.234,56" to "1234.56", if decimalComma is set.
+// The dots must group the integer digits by thousands, ex: "12.5" is
+// 	rejected, the separators can't be told apart otherwise.
+func normalizeDecimal(value string, decimalComma bool) (string, error) {
+	if !decimalComma {
+		return value, nil
+	}
+
+	integer, fraction := value, ""
+	if i := strings.LastIndex(value, ","); i >= 0 {
+		integer, fraction = value[:i], "."+value[i+1:]
+	}
+	if strings.Contains(integer, ",") || strings.Count(fraction, ".") > 1 {
+		return "", fmt.Errorf("Ambiguous decimal separator: %v", value)
+	}
+
+	groups := strings.Split(integer, ".")
+	if lead := strings.TrimLeft(groups[0], "+-"); len(groups) > 1 && (lead == "" || len(lead) > 3) {
+		return "", fmt.Errorf("Ambiguous decimal separator: %v", value)
+	}
+	for _, group := range groups[1:] {
+		if len(group) != 3 {
+			return "", fmt.Errorf("Ambiguous decimal separator: %v", value)
+		}
+	}
+	return strings.Join(groups, "") + fraction, nil
+}
+
+// Decimal is a decimal amount of the reports, ex: the price and the
+// 	settlement amount.
+// The amount is kept as the decimal string with dot separator, float64
+// 	can't represent all the decimal amounts exactly, ex: 0.1.
+type Decimal string
+
+// decimalType is the reflect type of Decimal.
+var decimalType = reflect.TypeOf(Decimal(""))
+
+// UnmarshalText validate and set the decimal.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	if _, err := strconv.ParseFloat(string(text), 64); err != nil {
+		return err
+	}
+	*d = Decimal(text)
+	return nil
+}
+
+// Float return the amount as float64, ex: for display or estimation.
+func (d Decimal) Float() (float64, error) {
+	return strconv.ParseFloat(string(d), 64)
+}
@@ -0,0 +1,163 @@
+package reports
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const settlementReport = "settlement-id\tsettlement-start-date\tsettlement-end-date\tdeposit-date\ttotal-amount\tcurrency\ttransaction-type\torder-id\tamount-type\tamount-description\tamount\tsku\tquantity-purchased\r\n" +
+	"11802794631\t2017-06-29T18:13:31+00:00\t2017-07-13T18:13:31+00:00\t2017-07-15T18:13:31+00:00\t1.234,56\tEUR\t\t\t\t\t\t\t\r\n" +
+	"11802794631\t\t\t\t\t\tOrder\t302-1234567-1234567\tItemPrice\tPrincipal\t1.019,99\tSKU-1\t1\r\n" +
+	"11802794631\t\t\t\t\t\tOrder\t302-1234567-1234567\tItemFees\tCommission\t-3,00\tSKU-1\t\r\n" +
+	"\r\n"
+
+func TestRowReader_Read(t *testing.T) {
+	Convey("Read merchant listings report", t, func() {
+		file, _ := os.Open("./exampleResponses/GetReport.txt")
+		defer file.Close()
+
+		reader, err := NewRowReader(file)
+		So(err, ShouldBeNil)
+		So(reader.Header()[0], ShouldEqual, "item-name")
+
+		row := MerchantListingsRow{}
+		So(reader.Read(&row), ShouldBeNil)
+		So(row.SellerSKU, ShouldEqual, "SKU2468")
+		So(row.Price, ShouldEqual, Decimal("24.99"))
+		So(row.Quantity, ShouldEqual, 5)
+		So(row.ItemCondition, ShouldEqual, 11)
+		So(row.ASIN1, ShouldEqual, "1933890517")
+		So(row.ItemNote, ShouldEqual, "")
+		So(row.Status, ShouldEqual, "Active")
+		So(reader.Line(), ShouldEqual, 2)
+
+		So(reader.Read(&row), ShouldEqual, io.EOF)
+	})
+
+	Convey("Read settlement report with decimal comma", t, func() {
+		reader, err := NewRowReader(strings.NewReader(settlementReport))
+		So(err, ShouldBeNil)
+		reader.DecimalComma = true
+
+		summary := SettlementRow{}
+		So(reader.Read(&summary), ShouldBeNil)
+		So(summary.SettlementID, ShouldEqual, "11802794631")
+		So(summary.TotalAmount, ShouldEqual, Decimal("1234.56"))
+		So(summary.Currency, ShouldEqual, "EUR")
+
+		row := SettlementRow{}
+		So(reader.Read(&row), ShouldBeNil)
+		So(row.TransactionType, ShouldEqual, "Order")
+		So(row.Amount, ShouldEqual, Decimal("1019.99"))
+		So(row.QuantityPurchased, ShouldEqual, 1)
+
+		So(reader.Read(&row), ShouldBeNil)
+		So(row.AmountType, ShouldEqual, "ItemFees")
+		So(row.Amount, ShouldEqual, Decimal("-3.00"))
+		So(row.QuantityPurchased, ShouldEqual, 0)
+		amount, err := row.Amount.Float()
+		So(err, ShouldBeNil)
+		So(amount, ShouldEqual, -3)
+
+		So(reader.Read(&row), ShouldEqual, io.EOF)
+	})
+
+	Convey("Return error when decimal comma not set", t, func() {
+		reader, _ := NewRowReader(strings.NewReader(settlementReport))
+
+		err := reader.Read(&SettlementRow{})
+
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "Line 2 column total-amount")
+	})
+
+	Convey("Return error when decimal separator is ambiguous", t, func() {
+		reader, _ := NewRowReader(strings.NewReader("sku\tprice\nSKU-1\t12.5\n"))
+		reader.DecimalComma = true
+
+		err := reader.Read(&OpenListingsRow{})
+
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "Ambiguous decimal separator")
+	})
+
+	Convey("Reset the fields of the empty columns", t, func() {
+		reader, _ := NewRowReader(strings.NewReader("sku\tprice\nSKU-1\t1.5\nSKU-2\t\n"))
+
+		row := OpenListingsRow{}
+		So(reader.Read(&row), ShouldBeNil)
+		So(row.Price, ShouldEqual, Decimal("1.5"))
+
+		So(reader.Read(&row), ShouldBeNil)
+		So(row.SKU, ShouldEqual, "SKU-2")
+		So(row.Price, ShouldEqual, Decimal(""))
+	})
+
+	Convey("Return error when value can't parse", t, func() {
+		reader, _ := NewRowReader(strings.NewReader("sku\tquantity\nSKU-1\tmany\n"))
+
+		err := reader.Read(&OpenListingsRow{})
+
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "Line 2 column quantity")
+	})
+
+	Convey("Return error when not a struct pointer", t, func() {
+		reader, _ := NewRowReader(strings.NewReader("sku\nSKU-1\n"))
+
+		err := reader.Read(OpenListingsRow{})
+
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Return error when report is empty", t, func() {
+		reader, err := NewRowReader(strings.NewReader(""))
+
+		So(reader, ShouldBeNil)
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestRowReader_ReadMap(t *testing.T) {
+	Convey("Read row as map with BOM in header", t, func() {
+		reader, _ := NewRowReader(strings.NewReader("\ufeffsku\tasin\nSKU-1\tB000000001\n"))
+
+		row, err := reader.ReadMap()
+
+		So(err, ShouldBeNil)
+		So(row, ShouldResemble, map[string]string{"sku": "SKU-1", "asin": "B000000001"})
+	})
+}
+
+func TestNormalizeDecimal(t *testing.T) {
+	Convey("Dots group the thousands with decimal comma", t, func() {
+		for value, expected := range map[string]string{
+			"1.234,56":     "1234.56",
+			"-1.234.567,8": "-1234567.8",
+			"19,99":        "19.99",
+			"1.234":        "1234",
+			"12":           "12",
+		} {
+			normalized, err := normalizeDecimal(value, true)
+			So(err, ShouldBeNil)
+			So(normalized, ShouldEqual, expected)
+		}
+	})
+
+	Convey("Misplaced separators are rejected with decimal comma", t, func() {
+		for _, value := range []string{"12.5", "1234.567,8", ".123,4", "1,234,5", "1,2.5"} {
+			_, err := normalizeDecimal(value, true)
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("Value is kept without decimal comma", t, func() {
+		normalized, err := normalizeDecimal("1,234.56", false)
+		So(err, ShouldBeNil)
+		So(normalized, ShouldEqual, "1,234.56")
+	})
+}
@@ -0,0 +1,36 @@
+package reports
+
+// Report processing status.
+const (
+	StatusSubmitted  = "_SUBMITTED_"
+	StatusInProgress = "_IN_PROGRESS_"
+	StatusCancelled  = "_CANCELLED_"
+	StatusDone       = "_DONE_"
+	StatusDoneNoData = "_DONE_NO_DATA_"
+)
+
+// ReportRequestInfo detailed information about a report request.
+// http://docs.developer.amazonservices.com/en_US/reports/Reports_ReportRequestInfo.html
+type ReportRequestInfo struct {
+	ReportRequestId        string `xml:"ReportRequestId"`
+	ReportType             string `xml:"ReportType"`
+	StartDate              string `xml:"StartDate"`
+	EndDate                string `xml:"EndDate"`
+	Scheduled              bool   `xml:"Scheduled"`
+	SubmittedDate          string `xml:"SubmittedDate"`
+	ReportProcessingStatus string `xml:"ReportProcessingStatus"`
+	GeneratedReportId      string `xml:"GeneratedReportId"`
+	StartedProcessingDate  string `xml:"StartedProcessingDate"`
+	CompletedDate          string `xml:"CompletedDate"`
+}
+
+// ReportInfo detailed information about a report.
+// http://docs.developer.amazonservices.com/en_US/reports/Reports_ReportInfo.html
+type ReportInfo struct {
+	ReportId         string `xml:"ReportId"`
+	ReportType       string `xml:"ReportType"`
+	ReportRequestId  string `xml:"ReportRequestId"`
+	AvailableDate    string `xml:"AvailableDate"`
+	Acknowledged     bool   `xml:"Acknowledged"`
+	AcknowledgedDate string `xml:"AcknowledgedDate"`
+}
@@ -0,0 +1,37 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/svvu/gomws/mws"
+)
+
+// GetReportRequestListPager create a pager for GetReportRequestList, the
+// 	items are *ReportRequestInfo.
+// The parameters are the same as GetReportRequestList.
+func (r Reports) GetReportRequestListPager(optional ...mws.Parameters) *mws.Pager {
+	fetch := func(ctx context.Context, nextToken string) (*mws.Response, error) {
+		if nextToken == "" {
+			return r.GetReportRequestListContext(ctx, optional...)
+		}
+		return r.GetReportRequestListByNextTokenContext(ctx, nextToken)
+	}
+
+	newInfo := func() interface{} { return &ReportRequestInfo{} }
+	return mws.NewPager(fetch, "ReportRequestInfo", newInfo).Pace(r.Client, "GetReportRequestListByNextToken")
+}
+
+// GetReportListPager create a pager for GetReportList, the items are
+// 	*ReportInfo.
+// The parameters are the same as GetReportList.
+func (r Reports) GetReportListPager(optional ...mws.Parameters) *mws.Pager {
+	fetch := func(ctx context.Context, nextToken string) (*mws.Response, error) {
+		if nextToken == "" {
+			return r.GetReportListContext(ctx, optional...)
+		}
+		return r.GetReportListByNextTokenContext(ctx, nextToken)
+	}
+
+	newInfo := func() interface{} { return &ReportInfo{} }
+	return mws.NewPager(fetch, "ReportInfo", newInfo).Pace(r.Client, "GetReportListByNextToken")
+}
@@ -3,6 +3,8 @@ package reports
 // Reference http://docs.developer.amazonservices.com/en_US/reports/Reports_Overview.html
 
 import (
+	"context"
+
 	"github.com/svvu/gomws/mws"
 )
 
@@ -22,6 +24,17 @@ func NewClient(config mws.Config) (*Reports, error) {
 	return report, nil
 }
 
+// NewPoolClient return the report client for the seller in the marketplace from the pool.
+func NewPoolClient(pool *mws.Pool, sellerID, marketplace string) (*Reports, error) {
+	report := new(Reports)
+	base, err := pool.Client(sellerID, marketplace, report.Version(), report.Name())
+	if err != nil {
+		return nil, err
+	}
+	report.Client = base
+	return report, nil
+}
+
 // Version return the current version of api
 func (r Reports) Version() string {
 	return "2009-01-01"
@@ -38,8 +51,14 @@ func (r Reports) Name() string {
 //  EndDate - string. The end of a date range used for selecting the data to report. Values in ISO 8601 date time format.
 //  ReportOptions - string. Additional information to pass to the report.
 //  MarketplaceIdList - []string. A list of one or more marketplace IDs for the marketplaces you are registered to sell in.
+// 		Default to the marketplaces set in the config.
 // http://docs.developer.amazonservices.com/en_US/reports/Reports_RequestReport.html
 func (r Reports) RequestReport(reportType string, optional ...mws.Parameters) (*mws.Response, error) {
+	return r.RequestReportContext(context.Background(), reportType, optional...)
+}
+
+// RequestReportContext is the context version of RequestReport.
+func (r Reports) RequestReportContext(ctx context.Context, reportType string, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{
 		"StartDate", "EndDate", "ReportOptions", "MarketplaceIdList",
 	}, optional)
@@ -47,10 +66,13 @@ func (r Reports) RequestReport(reportType string, optional ...mws.Parameters) (*
 		"Action":     "RequestReport",
 		"ReportType": reportType,
 	}.Merge(op)
+	if _, ok := params["MarketplaceIdList"]; !ok && len(r.Marketplaces) > 0 {
+		params["MarketplaceIdList"] = r.Marketplaces
+	}
 
 	structuredParams := params.StructureKeys("MarketplaceIdList", "Id")
 
-	return r.SendRequest(structuredParams)
+	return r.SendRequestContext(ctx, structuredParams)
 }
 
 // GetReportRequestList Returns a list of report requests that you can use to get the ReportRequestId for a report.
@@ -65,6 +87,11 @@ func (r Reports) RequestReport(reportType string, optional ...mws.Parameters) (*
 //  RequestedToDate: string. The end of the date range used for selecting the data to report, in ISO 8601 date time format .
 // http://docs.developer.amazonservices.com/en_US/reports/Reports_GetReportRequestList.html
 func (r Reports) GetReportRequestList(optional ...mws.Parameters) (*mws.Response, error) {
+	return r.GetReportRequestListContext(context.Background(), optional...)
+}
+
+// GetReportRequestListContext is the context version of GetReportRequestList.
+func (r Reports) GetReportRequestListContext(ctx context.Context, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{
 		"ReportRequestIdList", "ReportTypeList", "ReportProcessingStatusList",
 		"MaxCount", "RequestedFromDate", "RequestedToDate",
@@ -74,18 +101,23 @@ func (r Reports) GetReportRequestList(optional ...mws.Parameters) (*mws.Response
 		StructureKeys("ReportTypeList", "Type").
 		StructureKeys("ReportProcessingStatusList", "Status")
 
-	return r.SendRequest(structuredParams)
+	return r.SendRequestContext(ctx, structuredParams)
 }
 
 // GetReportRequestListByNextToken Returns a list of report requests using the NextToken, which was supplied by a previous request to either GetReportRequestListByNextToken or GetReportRequestList, where the value of HasNext was true in that previous request.
 // http://docs.developer.amazonservices.com/en_US/reports/Reports_GetReportRequestListByNextToken.html
 func (r Reports) GetReportRequestListByNextToken(nextToken string) (*mws.Response, error) {
+	return r.GetReportRequestListByNextTokenContext(context.Background(), nextToken)
+}
+
+// GetReportRequestListByNextTokenContext is the context version of GetReportRequestListByNextToken.
+func (r Reports) GetReportRequestListByNextTokenContext(ctx context.Context, nextToken string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":    "GetReportRequestListByNextToken",
 		"NextToken": nextToken,
 	}
 
-	return r.SendRequest(params)
+	return r.SendRequestContext(ctx, params)
 }
 
 // GetReportRequestCount Returns a count of report requests that have been submitted to Amazon MWS for processing.
@@ -98,6 +130,11 @@ func (r Reports) GetReportRequestListByNextToken(nextToken string) (*mws.Respons
 //  RequestedToDate: string. The end of the date range used for selecting the data to report, in ISO 8601 date time format .
 // http://docs.developer.amazonservices.com/en_US/reports/Reports_GetReportRequestCount.html
 func (r Reports) GetReportRequestCount(optional ...mws.Parameters) (*mws.Response, error) {
+	return r.GetReportRequestCountContext(context.Background(), optional...)
+}
+
+// GetReportRequestCountContext is the context version of GetReportRequestCount.
+func (r Reports) GetReportRequestCountContext(ctx context.Context, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{
 		"RequestedFromDate", "RequestedToDate", "ReportTypeList", "ReportProcessingStatusList",
 	}, optional)
@@ -105,7 +142,7 @@ func (r Reports) GetReportRequestCount(optional ...mws.Parameters) (*mws.Respons
 	structuredParams := params.StructureKeys("ReportTypeList", "Type").
 		StructureKeys("ReportProcessingStatusList", "Status")
 
-	return r.SendRequest(structuredParams)
+	return r.SendRequestContext(ctx, structuredParams)
 }
 
 // CancelReportRequests Cancels one or more report requests.
@@ -119,6 +156,11 @@ func (r Reports) GetReportRequestCount(optional ...mws.Parameters) (*mws.Respons
 //  RequestedToDate: string. The end of the date range used for selecting the data to report, in ISO 8601 date time format .
 // http://docs.developer.amazonservices.com/en_US/reports/Reports_CancelReportRequests.html
 func (r Reports) CancelReportRequests(optional ...mws.Parameters) (*mws.Response, error) {
+	return r.CancelReportRequestsContext(context.Background(), optional...)
+}
+
+// CancelReportRequestsContext is the context version of CancelReportRequests.
+func (r Reports) CancelReportRequestsContext(ctx context.Context, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{
 		"ReportRequestIdList", "ReportTypeList", "ReportProcessingStatusList",
 		"RequestedFromDate", "RequestedToDate",
@@ -128,7 +170,7 @@ func (r Reports) CancelReportRequests(optional ...mws.Parameters) (*mws.Response
 		StructureKeys("ReportTypeList", "Type").
 		StructureKeys("ReportProcessingStatusList", "Status")
 
-	return r.SendRequest(structuredParams)
+	return r.SendRequestContext(ctx, structuredParams)
 }
 
 // GetReportList Returns a list of reports that were created in the previous 90 days.
@@ -141,6 +183,11 @@ func (r Reports) CancelReportRequests(optional ...mws.Parameters) (*mws.Response
 // 	ReportRequestIdList - []string. A structured list of ReportRequestId values. If you pass in ReportRequestId values, other query conditions are ignored.
 // http://docs.developer.amazonservices.com/en_US/reports/Reports_GetReportList.html
 func (r Reports) GetReportList(optional ...mws.Parameters) (*mws.Response, error) {
+	return r.GetReportListContext(context.Background(), optional...)
+}
+
+// GetReportListContext is the context version of GetReportList.
+func (r Reports) GetReportListContext(ctx context.Context, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{
 		"MaxCount", "ReportTypeList", "Acknowledged",
 		"AvailableFromDate", "AvailableToDate", "ReportRequestIdList",
@@ -149,18 +196,23 @@ func (r Reports) GetReportList(optional ...mws.Parameters) (*mws.Response, error
 	structuredParams := params.StructureKeys("ReportRequestIdList", "Id").
 		StructureKeys("ReportTypeList", "Type")
 
-	return r.SendRequest(structuredParams)
+	return r.SendRequestContext(ctx, structuredParams)
 }
 
 // GetReportListByNextToken Returns a list of reports using the NextToken, which was supplied by a previous request to either GetReportListByNextToken or GetReportList, where the value of HasNext was true in the previous call.
 // http://docs.developer.amazonservices.com/en_US/reports/Reports_GetReportListByNextToken.html
 func (r Reports) GetReportListByNextToken(nextToken string) (*mws.Response, error) {
+	return r.GetReportListByNextTokenContext(context.Background(), nextToken)
+}
+
+// GetReportListByNextTokenContext is the context version of GetReportListByNextToken.
+func (r Reports) GetReportListByNextTokenContext(ctx context.Context, nextToken string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":    "GetReportListByNextToken",
 		"NextToken": nextToken,
 	}
 
-	return r.SendRequest(params)
+	return r.SendRequestContext(ctx, params)
 }
 
 // GetReportCount Returns a count of the reports, created in the previous 90 days, with a status of _DONE_ and that are available for download.
@@ -171,6 +223,11 @@ func (r Reports) GetReportListByNextToken(nextToken string) (*mws.Response, erro
 //  AvailableToDate: string. The end of the date range used for selecting the data to report, in ISO 8601 date time format .
 // http://docs.developer.amazonservices.com/en_US/reports/Reports_GetReportCount.html
 func (r Reports) GetReportCount(optional ...mws.Parameters) (*mws.Response, error) {
+	return r.GetReportCountContext(context.Background(), optional...)
+}
+
+// GetReportCountContext is the context version of GetReportCount.
+func (r Reports) GetReportCountContext(ctx context.Context, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{
 		"ReportTypeList", "Acknowledged",
 		"AvailableFromDate", "AvailableToDate",
@@ -178,18 +235,23 @@ func (r Reports) GetReportCount(optional ...mws.Parameters) (*mws.Response, erro
 	params := mws.Parameters{"Action": "GetReportCount"}.Merge(op)
 	structuredParams := params.StructureKeys("ReportTypeList", "Type")
 
-	return r.SendRequest(structuredParams)
+	return r.SendRequestContext(ctx, structuredParams)
 }
 
 // GetReport Returns the contents of a report and the Content-MD5 header for the returned report body.
 // http://docs.developer.amazonservices.com/en_US/reports/Reports_GetReport.html
 func (r Reports) GetReport(reportID string) (*mws.Response, error) {
+	return r.GetReportContext(context.Background(), reportID)
+}
+
+// GetReportContext is the context version of GetReport.
+func (r Reports) GetReportContext(ctx context.Context, reportID string) (*mws.Response, error) {
 	params := mws.Parameters{
 		"Action":   "GetReport",
 		"ReportId": reportID,
 	}
 
-	return r.SendRequest(params)
+	return r.SendRequestContext(ctx, params)
 }
 
 // ManageReportSchedule Creates, updates, or deletes a report request schedule for a specified report type.
@@ -203,6 +265,11 @@ func (r Reports) GetReport(reportID string) (*mws.Response, error) {
 //  ScheduleDate - string. The date when the next report request is scheduled to be submitted.
 // 		Value can be no more than 366 days in the future. In ISO 8601 date time format .
 func (r Reports) ManageReportSchedule(reportType string, schedule string, optional ...mws.Parameters) (*mws.Response, error) {
+	return r.ManageReportScheduleContext(context.Background(), reportType, schedule, optional...)
+}
+
+// ManageReportScheduleContext is the context version of ManageReportSchedule.
+func (r Reports) ManageReportScheduleContext(ctx context.Context, reportType string, schedule string, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{"ScheduleDate"}, optional)
 	params := mws.Parameters{
 		"Action":     "ManageReportSchedule",
@@ -210,27 +277,37 @@ func (r Reports) ManageReportSchedule(reportType string, schedule string, option
 		"Schedule":   schedule,
 	}.Merge(op)
 
-	return r.SendRequest(params)
+	return r.SendRequestContext(ctx, params)
 }
 
 // GetReportScheduleList Returns a list of order report requests that are scheduled to be submitted to Amazon MWS for processing.
 // http://docs.developer.amazonservices.com/en_US/reports/Reports_GetReportScheduleList.html
 func (r Reports) GetReportScheduleList(optional ...mws.Parameters) (*mws.Response, error) {
+	return r.GetReportScheduleListContext(context.Background(), optional...)
+}
+
+// GetReportScheduleListContext is the context version of GetReportScheduleList.
+func (r Reports) GetReportScheduleListContext(ctx context.Context, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{"ReportTypeList"}, optional)
 	params := mws.Parameters{"Action": "GetReportScheduleList"}.Merge(op)
 	structuredParams := params.StructureKeys("ReportTypeList", "Type")
 
-	return r.SendRequest(structuredParams)
+	return r.SendRequestContext(ctx, structuredParams)
 }
 
 // GetReportScheduleCount Returns a count of order report requests that are scheduled to be submitted to Amazon MWS.
 // http://docs.developer.amazonservices.com/en_US/reports/Reports_GetReportScheduleCount.html
 func (r Reports) GetReportScheduleCount(optional ...mws.Parameters) (*mws.Response, error) {
+	return r.GetReportScheduleCountContext(context.Background(), optional...)
+}
+
+// GetReportScheduleCountContext is the context version of GetReportScheduleCount.
+func (r Reports) GetReportScheduleCountContext(ctx context.Context, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{"ReportTypeList"}, optional)
 	params := mws.Parameters{"Action": "GetReportScheduleCount"}.Merge(op)
 	structuredParams := params.StructureKeys("ReportTypeList", "Type")
 
-	return r.SendRequest(structuredParams)
+	return r.SendRequestContext(ctx, structuredParams)
 }
 
 // UpdateReportAcknowledgements Updates the acknowledged status of one or more reports.
@@ -238,6 +315,11 @@ func (r Reports) GetReportScheduleCount(optional ...mws.Parameters) (*mws.Respon
 // Optional Parameters:
 //  Acknowledged - string. A Boolean value that indicates that you have received and stored a report.
 func (r Reports) UpdateReportAcknowledgements(ids []string, optional ...mws.Parameters) (*mws.Response, error) {
+	return r.UpdateReportAcknowledgementsContext(context.Background(), ids, optional...)
+}
+
+// UpdateReportAcknowledgementsContext is the context version of UpdateReportAcknowledgements.
+func (r Reports) UpdateReportAcknowledgementsContext(ctx context.Context, ids []string, optional ...mws.Parameters) (*mws.Response, error) {
 	op := mws.OptionalParams([]string{"Acknowledged"}, optional)
 	params := mws.Parameters{
 		"Action":       "UpdateReportAcknowledgements",
@@ -245,5 +327,5 @@ func (r Reports) UpdateReportAcknowledgements(ids []string, optional ...mws.Para
 	}.Merge(op)
 	structuredParams := params.StructureKeys("ReportIdList", "Id")
 
-	return r.SendRequest(structuredParams)
+	return r.SendRequestContext(ctx, structuredParams)
 }
@@ -0,0 +1,19 @@
+package reports
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws"
+)
+
+func TestNewPoolClient(t *testing.T) {
+	pool := mws.NewPool(mws.StaticProvider{Credential: mws.Credential{AccessKey: "AccessKey", SecretKey: "SecretKey"}})
+	pool.Authorize("SellerID", "AuthToken")
+
+	Convey("Reports client of the seller from the pool", t, func() {
+		client, err := NewPoolClient(pool, "SellerID", "UK")
+		So(err, ShouldBeNil)
+		So(client.Path(), ShouldEqual, "/Reports/2009-01-01")
+	})
+}
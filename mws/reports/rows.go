@@ -0,0 +1,110 @@
+package reports
+
+// Predefined row types of the common flat file reports, to be used with
+// 	RowReader.
+// Reports may have more columns than the row types, define a custom row
+// 	type if other columns are needed.
+
+// OpenListingsRow is the row of _GET_FLAT_FILE_OPEN_LISTINGS_DATA_.
+type OpenListingsRow struct {
+	SKU               string  `tsv:"sku"`
+	ASIN              string  `tsv:"asin"`
+	Price             Decimal `tsv:"price"`
+	Quantity          int     `tsv:"quantity"`
+	BusinessPrice     Decimal `tsv:"Business Price"`
+	QuantityPriceType string  `tsv:"Quantity Price Type"`
+}
+
+// MerchantListingsRow is the row of _GET_MERCHANT_LISTINGS_DATA_.
+type MerchantListingsRow struct {
+	ItemName                string  `tsv:"item-name"`
+	ItemDescription         string  `tsv:"item-description"`
+	ListingID               string  `tsv:"listing-id"`
+	SellerSKU               string  `tsv:"seller-sku"`
+	Price                   Decimal `tsv:"price"`
+	Quantity                int     `tsv:"quantity"`
+	OpenDate                string  `tsv:"open-date"`
+	ImageURL                string  `tsv:"image-url"`
+	ItemIsMarketplace       bool    `tsv:"item-is-marketplace"`
+	ProductIDType           int     `tsv:"product-id-type"`
+	ItemNote                string  `tsv:"item-note"`
+	ItemCondition           int     `tsv:"item-condition"`
+	ASIN1                   string  `tsv:"asin1"`
+	ASIN2                   string  `tsv:"asin2"`
+	ASIN3                   string  `tsv:"asin3"`
+	WillShipInternationally string  `tsv:"will-ship-internationally"`
+	ExpeditedShipping       string  `tsv:"expedited-shipping"`
+	ProductID               string  `tsv:"product-id"`
+	AddDelete               string  `tsv:"add-delete"`
+	PendingQuantity         int     `tsv:"pending-quantity"`
+	FulfillmentChannel      string  `tsv:"fulfillment-channel"`
+	MerchantShippingGroup   string  `tsv:"merchant-shipping-group"`
+	Status                  string  `tsv:"status"`
+}
+
+// AllOrdersRow is the row of _GET_FLAT_FILE_ALL_ORDERS_DATA_BY_LAST_UPDATE_
+// 	and _GET_FLAT_FILE_ALL_ORDERS_DATA_BY_ORDER_DATE_.
+type AllOrdersRow struct {
+	AmazonOrderID         string  `tsv:"amazon-order-id"`
+	MerchantOrderID       string  `tsv:"merchant-order-id"`
+	PurchaseDate          string  `tsv:"purchase-date"`
+	LastUpdatedDate       string  `tsv:"last-updated-date"`
+	OrderStatus           string  `tsv:"order-status"`
+	FulfillmentChannel    string  `tsv:"fulfillment-channel"`
+	SalesChannel          string  `tsv:"sales-channel"`
+	OrderChannel          string  `tsv:"order-channel"`
+	URL                   string  `tsv:"url"`
+	ShipServiceLevel      string  `tsv:"ship-service-level"`
+	ProductName           string  `tsv:"product-name"`
+	SKU                   string  `tsv:"sku"`
+	ASIN                  string  `tsv:"asin"`
+	ItemStatus            string  `tsv:"item-status"`
+	Quantity              int     `tsv:"quantity"`
+	Currency              string  `tsv:"currency"`
+	ItemPrice             Decimal `tsv:"item-price"`
+	ItemTax               Decimal `tsv:"item-tax"`
+	ShippingPrice         Decimal `tsv:"shipping-price"`
+	ShippingTax           Decimal `tsv:"shipping-tax"`
+	GiftWrapPrice         Decimal `tsv:"gift-wrap-price"`
+	GiftWrapTax           Decimal `tsv:"gift-wrap-tax"`
+	ItemPromotionDiscount Decimal `tsv:"item-promotion-discount"`
+	ShipPromotionDiscount Decimal `tsv:"ship-promotion-discount"`
+	ShipCity              string  `tsv:"ship-city"`
+	ShipState             string  `tsv:"ship-state"`
+	ShipPostalCode        string  `tsv:"ship-postal-code"`
+	ShipCountry           string  `tsv:"ship-country"`
+	PromotionIDs          string  `tsv:"promotion-ids"`
+	IsBusinessOrder       bool    `tsv:"is-business-order"`
+	PurchaseOrderNumber   string  `tsv:"purchase-order-number"`
+	PriceDesignation      string  `tsv:"price-designation"`
+}
+
+// SettlementRow is the row of _GET_V2_SETTLEMENT_REPORT_DATA_FLAT_FILE_V2_.
+// The first row is the summary of the settlement, only the settlement
+// 	columns and the total amount are set.
+type SettlementRow struct {
+	SettlementID             string  `tsv:"settlement-id"`
+	SettlementStartDate      string  `tsv:"settlement-start-date"`
+	SettlementEndDate        string  `tsv:"settlement-end-date"`
+	DepositDate              string  `tsv:"deposit-date"`
+	TotalAmount              Decimal `tsv:"total-amount"`
+	Currency                 string  `tsv:"currency"`
+	TransactionType          string  `tsv:"transaction-type"`
+	OrderID                  string  `tsv:"order-id"`
+	MerchantOrderID          string  `tsv:"merchant-order-id"`
+	AdjustmentID             string  `tsv:"adjustment-id"`
+	ShipmentID               string  `tsv:"shipment-id"`
+	MarketplaceName          string  `tsv:"marketplace-name"`
+	AmountType               string  `tsv:"amount-type"`
+	AmountDescription        string  `tsv:"amount-description"`
+	Amount                   Decimal `tsv:"amount"`
+	FulfillmentID            string  `tsv:"fulfillment-id"`
+	PostedDate               string  `tsv:"posted-date"`
+	PostedDateTime           string  `tsv:"posted-date-time"`
+	OrderItemCode            string  `tsv:"order-item-code"`
+	MerchantOrderItemID      string  `tsv:"merchant-order-item-id"`
+	MerchantAdjustmentItemID string  `tsv:"merchant-adjustment-item-id"`
+	SKU                      string  `tsv:"sku"`
+	QuantityPurchased        int     `tsv:"quantity-purchased"`
+	PromotionID              string  `tsv:"promotion-id"`
+}
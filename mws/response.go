@@ -2,14 +2,19 @@ package mws
 
 import (
 	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
-	"strings"
-
-	"github.com/pkg/errors"
+	"path/filepath"
+	"strconv"
+	"time"
 )
 
 // Response a custom http response with additional helper methods.
@@ -17,6 +22,20 @@ type Response struct {
 	*http.Response
 
 	Error error
+	// Attempts made to get the response, include the retries.
+	Attempts []Attempt
+}
+
+// Quota is the hourly request quota of the operation, returned in the
+// 	response headers.
+// http://docs.developer.amazonservices.com/en_US/dev_guide/DG_ResponseFormat.html
+type Quota struct {
+	// Max number of requests can be sent in the hour.
+	Max float64
+	// Number of requests remained in the hour.
+	Remaining float64
+	// Time when the quota will be reset.
+	ResetsOn time.Time
 }
 
 // NewResponse return a new mws response.
@@ -27,6 +46,55 @@ func NewResponse(resp *http.Response) *Response {
 	return response
 }
 
+// Quota return the request quota from the x-mws-quota-* headers.
+// If the response doesn't have the quota headers, false will be returned.
+func (resp *Response) Quota() (Quota, bool) {
+	quota := Quota{}
+	if resp.Header == nil || resp.Header.Get("x-mws-quota-max") == "" {
+		return quota, false
+	}
+
+	var err error
+	quota.Max, err = strconv.ParseFloat(resp.Header.Get("x-mws-quota-max"), 64)
+	if err != nil {
+		return quota, false
+	}
+	quota.Remaining, err = strconv.ParseFloat(resp.Header.Get("x-mws-quota-remaining"), 64)
+	if err != nil {
+		return quota, false
+	}
+	quota.ResetsOn, _ = time.Parse(time.RFC3339, resp.Header.Get("x-mws-quota-resetsOn"))
+
+	return quota, true
+}
+
+// RequestID return the id of the request from the x-mws-request-id header.
+// If the header is not set, the request id in error response body will be used.
+func (resp *Response) RequestID() string {
+	if resp.Header != nil {
+		if id := resp.Header.Get("x-mws-request-id"); id != "" {
+			return id
+		}
+	}
+
+	var apiErr *APIError
+	if errors.As(resp.Error, &apiErr) {
+		return apiErr.RequestID
+	}
+	return ""
+}
+
+// Timestamp return the time the response was generated from the
+// 	x-mws-timestamp header.
+// If the header is not set or invalid, zero time will be returned.
+func (resp *Response) Timestamp() time.Time {
+	if resp.Header == nil {
+		return time.Time{}
+	}
+	timestamp, _ := time.Parse(time.RFC3339, resp.Header.Get("x-mws-timestamp"))
+	return timestamp
+}
+
 // ResultParser create a new node parser for response body.
 func (resp *Response) ResultParser() (*ResultParser, error) {
 	body, err := ioutil.ReadAll(resp.Body)
@@ -37,9 +105,39 @@ func (resp *Response) ResultParser() (*ResultParser, error) {
 	return NewResultParser(body)
 }
 
+// VerifiedBody return a reader of the body which verify the content with the
+// 	Content-MD5 header.
+// The md5 is computed while reading, once the end of body reached, a
+// 	*ChecksumError is returned instead of io.EOF if the md5 doesn't match.
+// If the response doesn't have the Content-MD5 header, the body is returned.
+func (resp *Response) VerifiedBody() io.Reader {
+	expected := ""
+	if resp.Header != nil {
+		expected = resp.Header.Get("Content-MD5")
+	}
+	if expected == "" {
+		return resp.Body
+	}
+
+	return &checksumReader{reader: resp.Body, hash: md5.New(), expected: expected}
+}
+
+// DecodedBody return a reader of the verified body decoded to UTF-8.
+// The charset in the Content-Type header is used if present, otherwise
+// 	the encoding of the region, ex: Shift_JIS for JP.
+func (resp *Response) DecodedBody(region string) (io.Reader, error) {
+	contentType := ""
+	if resp.Header != nil {
+		contentType = resp.Header.Get("Content-Type")
+	}
+	return NewDecodeReader(resp.VerifiedBody(), region, contentType)
+}
+
 // WriteBodyTo write the response body to the destination output.
+// The body is verified by the Content-MD5 header if present, a *ChecksumError
+// 	is returned on mismatch, and the content written should be discarded.
 func (resp *Response) WriteBodyTo(out io.Writer) error {
-	_, err := io.Copy(out, resp.Body)
+	_, err := io.Copy(out, resp.VerifiedBody())
 	if err != nil {
 		return err
 	}
@@ -48,14 +146,29 @@ func (resp *Response) WriteBodyTo(out io.Writer) error {
 }
 
 // ExportTo export the body to a file with path.
+// The body is written to a temp file in the same directory first, and only
+// 	moved to the path after the body is fully written and verified.
 func (resp *Response) ExportTo(path string) error {
-	out, err := os.Create(path)
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	out, err := ioutil.TempFile(dir, "."+name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := out.Name()
+
+	err = resp.WriteBodyTo(out)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
+		os.Remove(tempPath)
 		return err
 	}
-	defer out.Close()
 
-	return resp.WriteBodyTo(out)
+	return os.Rename(tempPath, path)
 }
 
 // Close make sure the body drained, and then close the connection to make the
@@ -65,8 +178,58 @@ func (resp *Response) Close() {
 	resp.Body.Close()
 }
 
+// ChecksumError is returned when the md5 of the body doesn't match the
+// 	Content-MD5 header.
+type ChecksumError struct {
+	// The base64 encoded md5 in the Content-MD5 header.
+	Expected string
+	// The base64 encoded md5 of the body received.
+	Actual string
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("Content-MD5 mismatch: expected %v, got %v", e.Expected, e.Actual)
+}
+
+// checksumReader compute the md5 of the content read, and compare it with the
+// 	expected md5 once the end reached.
+type checksumReader struct {
+	reader   io.Reader
+	hash     hash.Hash
+	expected string
+}
+
+// Read read from the underlying reader and update the md5.
+func (cr *checksumReader) Read(p []byte) (int, error) {
+	n, err := cr.reader.Read(p)
+	cr.hash.Write(p[:n])
+	if err == io.EOF {
+		actual := base64.StdEncoding.EncodeToString(cr.hash.Sum(nil))
+		if actual != cr.expected {
+			return n, &ChecksumError{Expected: cr.expected, Actual: actual}
+		}
+	}
+	return n, err
+}
+
+// contextReader is a body reader which stop reading once the context is done.
+type contextReader struct {
+	ctx context.Context
+	io.ReadCloser
+}
+
+// Read return the context error if the context is done, otherwise read from
+// 	the underlying body.
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.ReadCloser.Read(p)
+}
+
 // parseResponseError parse the xml body to extract the detail error msg for status code below.
 // If fail to extract the info, it will default to response status.
+// The error returned is an *APIError.
 //
 // https://docs.developer.amazonservices.com/en_US/dev_guide/DG_Errors.html#ErrorMessages_Service_errors
 // Table 1. Common HTTP error status codes
@@ -81,37 +244,44 @@ func (resp *Response) Close() {
 // QuotaExceeded				503				The total number of requests in an hour was exceeded.
 // RequestThrottled				503				The frequency of requests was greater than allowed.
 func parseResponseError(resp *Response) error {
-	switch resp.StatusCode {
-	case 200:
+	if resp.StatusCode == 200 {
 		return nil
-	case 400, 401, 403, 404, 500, 503:
-		baseErr := fmt.Errorf("Request not success. Reason: %v", resp.Status)
+	}
+
+	apiErr := &APIError{StatusCode: resp.StatusCode, Status: resp.Status}
+	if resp.Header != nil {
+		apiErr.RequestID = resp.Header.Get("x-mws-request-id")
+	}
 
+	switch resp.StatusCode {
+	case 400, 401, 403, 404, 500, 503:
 		// Reset the body, so parsing error won't darin the body.
 		body, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
-			return baseErr
+			return apiErr
 		}
 		resp.Body.Close()
 		resp.Body = ioutil.NopCloser(bytes.NewBuffer(body))
 
 		node, err := NewResultParser(body)
-		if err != nil || !node.HasErrorNodes() {
-			return baseErr
+		if err != nil {
+			return apiErr
+		}
+		if apiErr.RequestID == "" {
+			apiErr.RequestID = node.RequestID()
+		}
+		if !node.HasErrorNodes() {
+			return apiErr
 		}
 
 		mwsErrors, err := node.GetMWSErrors()
 		if err != nil {
-			return baseErr
-		}
-
-		msgs := []string{}
-		for _, mwsErr := range mwsErrors {
-			msgs = append(msgs, mwsErr.Message)
+			return apiErr
 		}
+		apiErr.Errors = mwsErrors
 
-		return errors.Wrap(errors.New(strings.Join(msgs, "\n")), baseErr.Error())
+		return apiErr
 	default:
-		return fmt.Errorf("Request not success. Reason: %v", resp.Status)
+		return apiErr
 	}
 }
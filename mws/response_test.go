@@ -2,10 +2,14 @@ package mws
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -44,6 +48,76 @@ func TestResponse_ResultParser(t *testing.T) {
 	})
 }
 
+func TestResponse_Quota(t *testing.T) {
+	Convey("when response has quota headers", t, func() {
+		resp := NewResponse(&http.Response{
+			StatusCode: 200,
+			Header: http.Header{
+				"X-Mws-Quota-Max":       []string{"200.0"},
+				"X-Mws-Quota-Remaining": []string{"150.0"},
+				"X-Mws-Quota-Resetson":  []string{"2013-04-11T22:00:00.000Z"},
+			},
+		})
+
+		quota, ok := resp.Quota()
+
+		Convey("return the quota", func() {
+			So(ok, ShouldBeTrue)
+			So(quota.Max, ShouldEqual, 200)
+			So(quota.Remaining, ShouldEqual, 150)
+			So(quota.ResetsOn, ShouldResemble, time.Date(2013, 4, 11, 22, 0, 0, 0, time.UTC))
+		})
+	})
+
+	Convey("when response has no quota headers", t, func() {
+		resp := NewResponse(&http.Response{StatusCode: 200, Header: http.Header{}})
+
+		_, ok := resp.Quota()
+
+		Convey("return not ok", func() {
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
+
+func TestResponse_RequestID(t *testing.T) {
+	Convey("when response has request id header", t, func() {
+		resp := NewResponse(&http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"X-Mws-Request-Id": []string{"header-id"}},
+		})
+
+		Convey("return the id in header", func() {
+			So(resp.RequestID(), ShouldEqual, "header-id")
+		})
+	})
+
+	Convey("when error response has request id in body", t, func() {
+		resp := NewResponse(&http.Response{
+			StatusCode: 400,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBuffer(errorTestExample())),
+		})
+
+		Convey("return the id in body", func() {
+			So(resp.RequestID(), ShouldEqual, "85c254bd-8214-49a3-95d2-9fadsdad566110")
+		})
+	})
+}
+
+func TestResponse_Timestamp(t *testing.T) {
+	Convey("when response has timestamp header", t, func() {
+		resp := NewResponse(&http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"X-Mws-Timestamp": []string{"2013-04-11T21:45:00.000Z"}},
+		})
+
+		Convey("return the timestamp", func() {
+			So(resp.Timestamp(), ShouldResemble, time.Date(2013, 4, 11, 21, 45, 0, 0, time.UTC))
+		})
+	})
+}
+
 func TestResponse_WriteBodyTo(t *testing.T) {
 	resp := NewResponse(&http.Response{
 		Body: ioutil.NopCloser(bytes.NewBufferString("foo bar")),
@@ -55,6 +129,71 @@ func TestResponse_WriteBodyTo(t *testing.T) {
 	Convey("Data in the response body be written to buffer", t, func() {
 		So(out.String(), ShouldEqual, "foo bar")
 	})
+
+	Convey("When Content-MD5 match the body", t, func() {
+		resp := NewResponse(&http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Md5": []string{Body{Content: []byte("foo bar")}.MD5()}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString("foo bar")),
+		})
+		out := bytes.NewBufferString("")
+
+		err := resp.WriteBodyTo(out)
+
+		So(err, ShouldBeNil)
+		So(out.String(), ShouldEqual, "foo bar")
+	})
+
+	Convey("When Content-MD5 not match the body", t, func() {
+		resp := NewResponse(&http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Md5": []string{Body{Content: []byte("foo")}.MD5()}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString("foo bar")),
+		})
+
+		err := resp.WriteBodyTo(ioutil.Discard)
+
+		var checksumErr *ChecksumError
+		So(errors.As(err, &checksumErr), ShouldBeTrue)
+		So(checksumErr.Expected, ShouldEqual, Body{Content: []byte("foo")}.MD5())
+		So(checksumErr.Actual, ShouldEqual, Body{Content: []byte("foo bar")}.MD5())
+	})
+}
+
+func TestResponse_ExportTo(t *testing.T) {
+	newResponse := func(md5 string) *Response {
+		return NewResponse(&http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Md5": []string{md5}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString("foo bar")),
+		})
+	}
+
+	Convey("When the body verified", t, func() {
+		dir, _ := ioutil.TempDir("", "export")
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "report.txt")
+
+		err := newResponse(Body{Content: []byte("foo bar")}.MD5()).ExportTo(path)
+
+		So(err, ShouldBeNil)
+		content, _ := ioutil.ReadFile(path)
+		So(string(content), ShouldEqual, "foo bar")
+		files, _ := ioutil.ReadDir(dir)
+		So(len(files), ShouldEqual, 1)
+	})
+
+	Convey("When the body not verified", t, func() {
+		dir, _ := ioutil.TempDir("", "export")
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "report.txt")
+
+		err := newResponse("bad md5").ExportTo(path)
+
+		So(err, ShouldHaveSameTypeAs, &ChecksumError{})
+		files, _ := ioutil.ReadDir(dir)
+		So(len(files), ShouldEqual, 0)
+	})
 }
 
 func TestParseResponseError(t *testing.T) {
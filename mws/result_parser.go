@@ -45,3 +45,17 @@ func (rp *ResultParser) GetMWSErrors() ([]Error, error) {
 	}
 	return errors, nil
 }
+
+// RequestID return the request id in the response metadata.
+// Error response use tag RequestID instead of RequestId.
+// If no request id found, empty string will be returned.
+func (rp *ResultParser) RequestID() string {
+	for _, key := range []string{"RequestId", "RequestID"} {
+		for _, node := range rp.FindByKey(key) {
+			if id, err := node.ToString(); err == nil {
+				return id
+			}
+		}
+	}
+	return ""
+}
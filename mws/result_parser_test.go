@@ -109,3 +109,21 @@ func TestResultParser_GetMWSErrors(t *testing.T) {
 		})
 	})
 }
+
+func TestResultParser_RequestID(t *testing.T) {
+	Convey("When response has response metadata", t, func() {
+		rp, _ := NewResultParser(noErrorTestExample())
+
+		Convey("Request id returned", func() {
+			So(rp.RequestID(), ShouldEqual, "d80c6c7b-f7c7-4fa7-bdd7-854711cb3bcc")
+		})
+	})
+
+	Convey("When response has no request id", t, func() {
+		rp, _ := NewResultParser([]byte("<foo>bar</foo>"))
+
+		Convey("Empty string returned", func() {
+			So(rp.RequestID(), ShouldEqual, "")
+		})
+	})
+}
@@ -0,0 +1,194 @@
+package mws
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/url"
+	"time"
+)
+
+// DefaultRetryableCodes the MWS error codes which are worth to retry.
+var DefaultRetryableCodes = []string{
+	"RequestThrottled", "QuotaExceeded", "InternalError", "ServiceUnavailable",
+}
+
+// DefaultRetryableStatusCodes the http status codes which are worth to retry,
+// 	when the response has no MWS errors.
+var DefaultRetryableStatusCodes = []int{500, 503}
+
+// NonIdempotentActions the actions which are not safe to send twice, ex:
+// 	SubmitFeed create a new feed submission for each request.
+var NonIdempotentActions = []string{
+	"SubmitFeed", "CancelFeedSubmissions", "RequestReport", "CancelReportRequests",
+	"ManageReportSchedule", "UpdateReportAcknowledgements",
+}
+
+// defaultMaxDelay the max delay between two attempts if not set.
+const defaultMaxDelay = time.Hour
+
+// RetryPolicy configure how the client retry the requests failed with
+// retryable MWS errors.
+// The delay before each retry grows exponentially from BaseDelay, and is
+// capped by MaxDelay.
+type RetryPolicy struct {
+	// Max number of attempts, include the first one.
+	MaxAttempts int
+	// Delay before the first retry.
+	BaseDelay time.Duration
+	// Max delay between two attempts. Default to 1 hour.
+	MaxDelay time.Duration
+	// Fraction of the delay to be randomized, value 0 - 1.
+	// Ex: 0.2 means the actual delay is between 80% - 100% of the delay.
+	Jitter float64
+	// MWS error codes which should be retried.
+	// 	Default to DefaultRetryableCodes, set an empty slice to retry none.
+	RetryableCodes []string
+	// Http status codes which should be retried when the response has no
+	// 	MWS errors. Default to DefaultRetryableStatusCodes.
+	RetryableStatusCodes []int
+	// Retry the requests with body or non-idempotent action when the
+	// 	transport error may happen after the request was sent, ex: timeout.
+	// By default, they are only retried when failed to connect.
+	RetryUnsafe bool
+}
+
+// NewRetryPolicy create a retry policy with default values.
+// 3 attempts, delay from 1 second up to 30 seconds with 20% jitter,
+// retry the DefaultRetryableCodes and DefaultRetryableStatusCodes.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            time.Second,
+		MaxDelay:             30 * time.Second,
+		Jitter:               0.2,
+		RetryableCodes:       DefaultRetryableCodes,
+		RetryableStatusCodes: DefaultRetryableStatusCodes,
+	}
+}
+
+// Attempt is the record for one attempt of sending the request.
+type Attempt struct {
+	// Attempt number, start from 1.
+	Number int
+	// Time when the attempt was sent.
+	Time time.Time
+	// Time waited before the attempt.
+	Delay time.Duration
+	// Http status code of the attempt, 0 if the request fail to send.
+	StatusCode int
+	// Error of the attempt, either the http client error or the MWS error.
+	Err error
+}
+
+// Retryable check whether or not the response should be retried.
+// A response is retryable if it contains any retryable MWS error code, or
+// 	has no MWS errors and a retryable status code, ex: 503 from a proxy.
+func (rp *RetryPolicy) Retryable(resp *Response) bool {
+	var apiErr *APIError
+	if !errors.As(resp.Error, &apiErr) {
+		return false
+	}
+
+	if len(apiErr.Errors) == 0 {
+		statusCodes := rp.RetryableStatusCodes
+		if statusCodes == nil {
+			statusCodes = DefaultRetryableStatusCodes
+		}
+		for _, statusCode := range statusCodes {
+			if apiErr.StatusCode == statusCode {
+				return true
+			}
+		}
+		return false
+	}
+
+	codes := rp.RetryableCodes
+	if codes == nil {
+		codes = DefaultRetryableCodes
+	}
+	for _, code := range apiErr.Codes() {
+		for _, retryableCode := range codes {
+			if code == retryableCode {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RetryableError check whether or not the request failed with the error
+// 	should be retried.
+// The errors of the http client sending the request are retryable, ex: the
+// 	timeout and connection reset. The context errors are not.
+// The request with body or non-idempotent action may be received by MWS
+// 	before the error, it's only retried when failed to connect, unless
+// 	RetryUnsafe is set.
+func (rp *RetryPolicy) RetryableError(req *Request, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) || urlErr.Op == "parse" {
+		return false
+	}
+	if rp.RetryUnsafe || idempotent(req) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// idempotent check whether or not the request is safe to be sent twice.
+func idempotent(req *Request) bool {
+	if req.Body != nil {
+		return false
+	}
+	for _, action := range NonIdempotentActions {
+		if req.Action == action {
+			return false
+		}
+	}
+	return true
+}
+
+// Delay return the time to wait before the next attempt, after n attempts.
+func (rp *RetryPolicy) Delay(n int) time.Duration {
+	maxDelay := rp.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxDelay
+	}
+
+	delay := rp.BaseDelay
+	if delay <= 0 {
+		return 0
+	}
+	for i := 1; i < n && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	if rp.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * rp.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// SleepContext pause for the duration, or until the context is done.
+// Return the context error if the context is done first.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,243 @@
+package mws
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/svvu/gomws/mws/mock"
+)
+
+const throttledBody = `<ErrorResponse><Error><Type>Sender</Type>` +
+	`<Code>RequestThrottled</Code><Message>Request is throttled</Message>` +
+	`</Error></ErrorResponse>`
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	Convey("Delay grows exponentially", t, func() {
+		So(policy.Delay(1), ShouldEqual, time.Second)
+		So(policy.Delay(2), ShouldEqual, 2*time.Second)
+		So(policy.Delay(3), ShouldEqual, 4*time.Second)
+	})
+
+	Convey("Delay is capped by max delay", t, func() {
+		So(policy.Delay(4), ShouldEqual, 5*time.Second)
+		So(policy.Delay(100), ShouldEqual, 5*time.Second)
+	})
+
+	Convey("Delay without max delay not overflow", t, func() {
+		unlimited := &RetryPolicy{BaseDelay: time.Second}
+		So(unlimited.Delay(2), ShouldEqual, 2*time.Second)
+		So(unlimited.Delay(1000), ShouldEqual, time.Hour)
+	})
+
+	Convey("Jitter reduce the delay within the fraction", t, func() {
+		jitterPolicy := &RetryPolicy{BaseDelay: time.Second, Jitter: 0.5}
+		for i := 0; i < 10; i++ {
+			delay := jitterPolicy.Delay(1)
+			So(delay, ShouldBeLessThanOrEqualTo, time.Second)
+			So(delay, ShouldBeGreaterThanOrEqualTo, 500*time.Millisecond)
+		}
+	})
+}
+
+func TestRetryPolicy_Retryable(t *testing.T) {
+	policy := NewRetryPolicy()
+
+	Convey("Response without error is not retryable", t, func() {
+		So(policy.Retryable(&Response{}), ShouldBeFalse)
+	})
+
+	Convey("Response with retryable code is retryable", t, func() {
+		resp := NewResponse(mock.NewResponse(503, throttledBody))
+		So(policy.Retryable(resp), ShouldBeTrue)
+	})
+
+	Convey("Response with other code is not retryable", t, func() {
+		resp := NewResponse(mock.NewResponse(400,
+			"<Error><Code>InvalidParameterValue</Code></Error>"))
+		So(policy.Retryable(resp), ShouldBeFalse)
+	})
+
+	Convey("Response with retryable status and no MWS errors is retryable", t, func() {
+		So(policy.Retryable(NewResponse(mock.NewResponse(503, "Service Unavailable"))), ShouldBeTrue)
+		So(policy.Retryable(NewResponse(mock.NewResponse(500, ""))), ShouldBeTrue)
+		So(policy.Retryable(NewResponse(mock.NewResponse(404, ""))), ShouldBeFalse)
+	})
+
+	Convey("Nil retryable codes default to DefaultRetryableCodes", t, func() {
+		resp := NewResponse(mock.NewResponse(503, throttledBody))
+		So((&RetryPolicy{}).Retryable(resp), ShouldBeTrue)
+		So((&RetryPolicy{RetryableCodes: []string{}}).Retryable(resp), ShouldBeFalse)
+	})
+
+	Convey("Transport error is retryable", t, func() {
+		req := &Request{Action: "ListOrders"}
+		So(policy.RetryableError(req, &url.Error{Op: "Post", URL: "https://host", Err: io.EOF}), ShouldBeTrue)
+		So(policy.RetryableError(req, &url.Error{Op: "Post", URL: "https://host", Err: context.Canceled}), ShouldBeFalse)
+		So(policy.RetryableError(req, errors.New("Can't find mws credential information")), ShouldBeFalse)
+	})
+
+	Convey("Unsafe request is only retryable when failed to connect", t, func() {
+		dialErr := &url.Error{Op: "Post", URL: "https://host", Err: &net.OpError{Op: "dial", Err: io.EOF}}
+		sentErr := &url.Error{Op: "Post", URL: "https://host", Err: io.EOF}
+		for _, req := range []*Request{{Action: "RequestReport"}, {Action: "ListOrders", Body: &Body{}}} {
+			So(policy.RetryableError(req, dialErr), ShouldBeTrue)
+			So(policy.RetryableError(req, sentErr), ShouldBeFalse)
+			So((&RetryPolicy{RetryUnsafe: true}).RetryableError(req, sentErr), ShouldBeTrue)
+		}
+	})
+}
+
+func TestClient_SendRequest_RetryTransportError(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			// Reset the connection without response.
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Write([]byte("<Result>ok</Result>"))
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.Endpoint = server.URL
+	client, _ := NewClient(config, testVersion, testClientName)
+
+	Convey("Retry the transport errors", t, func() {
+		client.Retry = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+		resp, err := client.SendRequest(testParams.params)
+		So(err, ShouldBeNil)
+		defer resp.Close()
+
+		So(resp.StatusCode, ShouldEqual, 200)
+		So(resp.Attempts, ShouldHaveLength, 3)
+		So(resp.Attempts[0].Err, ShouldNotBeNil)
+	})
+
+	Convey("Return the error once attempts are exhausted", t, func() {
+		atomic.StoreInt32(&requests, 0)
+		client.Retry = &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+		_, err := client.SendRequest(testParams.params)
+		So(err, ShouldNotBeNil)
+		So(atomic.LoadInt32(&requests), ShouldEqual, 2)
+	})
+
+	Convey("Not retry the body request once sent", t, func() {
+		atomic.StoreInt32(&requests, 0)
+		client.Retry = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+		params := Parameters{"Action": "SubmitFeed", "FeedType": "_POST_PRODUCT_DATA_"}
+		_, err := client.SendBodyRequest(params, Body{ContentType: "text/xml", Content: []byte("<Feed/>")})
+		So(err, ShouldNotBeNil)
+		So(atomic.LoadInt32(&requests), ShouldEqual, 1)
+	})
+}
+
+func TestClient_SendRequest_Retry(t *testing.T) {
+	server := mock.NewServer()
+	defer server.Close()
+
+	var mu sync.Mutex
+	timestamps := []string{}
+	server.SetResponseHandler(func(r *http.Request) *http.Response {
+		r.ParseForm()
+		mu.Lock()
+		defer mu.Unlock()
+		timestamps = append(timestamps, r.PostForm.Get("Timestamp"))
+		if len(timestamps) < 3 {
+			return mock.NewResponse(503, throttledBody)
+		}
+		return mock.NewResponse(200, "<Result>ok</Result>")
+	})
+	// sent return the timestamps received, and reset them.
+	sent := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		received := timestamps
+		timestamps = []string{}
+		return received
+	}
+
+	count := 0
+	now = func() string {
+		count++
+		return "2015-10-20T22:46:0" + strconv.Itoa(count) + "Z"
+	}
+	defer func() { now = func() string { return testTimestamp } }()
+
+	client, _ := NewClient(testConfig(), testVersion, testClientName)
+	client.Host = server.Host()
+	client.Transport = mock.NoVerifyTransport()
+
+	Convey("When retry policy allow enough attempts", t, func() {
+		sent()
+		client.Retry = &RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+		resp, err := client.SendRequest(testParams.params)
+		defer resp.Close()
+
+		Convey("Success response returned", func() {
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 200)
+			So(resp.Error, ShouldBeNil)
+		})
+
+		Convey("Attempt history recorded", func() {
+			So(len(resp.Attempts), ShouldEqual, 3)
+			So(resp.Attempts[0].StatusCode, ShouldEqual, 503)
+			So(resp.Attempts[0].Err, ShouldNotBeNil)
+			So(resp.Attempts[1].Delay, ShouldEqual, time.Millisecond)
+			So(resp.Attempts[2].Delay, ShouldEqual, 2*time.Millisecond)
+			So(resp.Attempts[2].StatusCode, ShouldEqual, 200)
+		})
+
+		timestamps := sent()
+		Convey("Each attempt signed with fresh timestamp", func() {
+			So(len(timestamps), ShouldEqual, 3)
+			So(timestamps[0], ShouldNotEqual, timestamps[1])
+			So(timestamps[1], ShouldNotEqual, timestamps[2])
+		})
+	})
+
+	Convey("When attempts are exhausted", t, func() {
+		sent()
+		client.Retry = &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+
+		resp, err := client.SendRequest(testParams.params)
+		defer resp.Close()
+
+		Convey("Last failed response returned", func() {
+			So(err, ShouldBeNil)
+			So(resp.StatusCode, ShouldEqual, 503)
+			So(resp.Error, ShouldNotBeNil)
+			So(len(resp.Attempts), ShouldEqual, 2)
+		})
+	})
+
+	Convey("When no retry policy", t, func() {
+		sent()
+		client.Retry = nil
+
+		resp, _ := client.SendRequest(testParams.params)
+		defer resp.Close()
+
+		Convey("Only one attempt made", func() {
+			So(resp.StatusCode, ShouldEqual, 503)
+			So(len(resp.Attempts), ShouldEqual, 1)
+			So(len(sent()), ShouldEqual, 1)
+		})
+	})
+}
@@ -0,0 +1,158 @@
+package mws
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/svvu/gomws/mws/throttling"
+)
+
+// RequestQuota is the throttling limit of an operation.
+type RequestQuota = throttling.Quota
+
+// DefaultRequestQuotas the documented quota for the operations, key by
+// 	"Name/Action", ex: "Orders/ListOrders".
+// Same table as throttling.DefaultQuotas, the changes apply to both the
+// 	Throttler and the mock server.
+var DefaultRequestQuotas = throttling.DefaultQuotas
+
+// bucket is a leaky bucket for one operation of one seller.
+// The level is the number of requests in the bucket, it leak one request per
+// 	restore rate.
+// When the hourly quota is used up, the bucket is blocked until it reset.
+type bucket struct {
+	level        float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// Throttler block the requests before they exceed the request quota.
+// Requests are limited per operation (API name + action) and per seller.
+// Operations without quota will not be throttled.
+type Throttler struct {
+	mu      sync.Mutex
+	quotas  map[string]RequestQuota
+	buckets map[string]*bucket
+}
+
+// NewThrottler create a throttler with the DefaultRequestQuotas.
+func NewThrottler() *Throttler {
+	quotas := map[string]RequestQuota{}
+	for key, quota := range DefaultRequestQuotas {
+		quotas[key] = quota
+	}
+
+	return &Throttler{
+		quotas:  quotas,
+		buckets: map[string]*bucket{},
+	}
+}
+
+// SetQuota override the quota for the operation.
+func (t *Throttler) SetQuota(name, action string, quota RequestQuota) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.quotas[throttling.Key(name, action)] = quota
+}
+
+// Quota get the quota for the operation.
+func (t *Throttler) Quota(name, action string) (RequestQuota, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	quota, ok := t.quotas[throttling.Key(name, action)]
+	return quota, ok
+}
+
+// Wait block until the request for the operation can be sent without
+// 	exceeding the quota, or the context is done.
+func (t *Throttler) Wait(ctx context.Context, name, action, sellerID string) error {
+	wait, ok := t.reserve(name, action, sellerID)
+	if !ok {
+		return ctx.Err()
+	}
+
+	if err := SleepContext(ctx, wait); err != nil {
+		t.cancel(name, action, sellerID)
+		return err
+	}
+	return nil
+}
+
+// reserve put a request in the bucket, and return the time to wait before
+// 	the request can be sent.
+// If the operation has no quota and is not blocked, false will be returned.
+func (t *Throttler) reserve(name, action, sellerID string) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	b := t.bucket(name, action, sellerID)
+	wait := b.blockedUntil.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+
+	quota, ok := t.quotas[throttling.Key(name, action)]
+	if !ok || quota.MaxRequests <= 0 || quota.RestoreRate <= 0 {
+		return wait, wait > 0
+	}
+
+	b.leak(quota, now)
+	b.level++
+
+	overflow := b.level - float64(quota.MaxRequests)
+	if restoreWait := time.Duration(overflow * float64(quota.RestoreRate)); restoreWait > wait {
+		wait = restoreWait
+	}
+	return wait, true
+}
+
+// Observe update the throttler with the hourly quota returned by MWS.
+// When no request remained, the requests for the operation will be blocked
+// 	until the quota reset.
+func (t *Throttler) Observe(name, action, sellerID string, quota Quota) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b := t.bucket(name, action, sellerID)
+	if quota.Remaining <= 0 && !quota.ResetsOn.IsZero() {
+		b.blockedUntil = quota.ResetsOn
+	} else {
+		b.blockedUntil = time.Time{}
+	}
+}
+
+// cancel take back the request reserved.
+func (t *Throttler) cancel(name, action, sellerID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b := t.bucket(name, action, sellerID)
+	if b.level >= 1 {
+		b.level--
+	}
+}
+
+// bucket get the bucket for the operation of the seller, create if not exist.
+func (t *Throttler) bucket(name, action, sellerID string) *bucket {
+	key := throttling.Key(name, action) + "/" + sellerID
+	b, ok := t.buckets[key]
+	if !ok {
+		b = &bucket{last: time.Now()}
+		t.buckets[key] = b
+	}
+	return b
+}
+
+// leak remove the requests restored since last leak.
+func (b *bucket) leak(quota RequestQuota, now time.Time) {
+	restored := float64(now.Sub(b.last)) / float64(quota.RestoreRate)
+	b.level -= restored
+	if b.level < 0 {
+		b.level = 0
+	}
+	b.last = now
+}
@@ -0,0 +1,109 @@
+package mws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewThrottler(t *testing.T) {
+	Convey("Throttler has the default quotas", t, func() {
+		throttler := NewThrottler()
+
+		quota, ok := throttler.Quota("Orders", "ListOrders")
+		So(ok, ShouldBeTrue)
+		So(quota, ShouldResemble, RequestQuota{MaxRequests: 6, RestoreRate: time.Minute})
+
+		quota, ok = throttler.Quota("Products", "GetMatchingProduct")
+		So(ok, ShouldBeTrue)
+		So(quota, ShouldResemble, RequestQuota{MaxRequests: 20, RestoreRate: 500 * time.Millisecond})
+	})
+
+	Convey("Override quota not change the defaults", t, func() {
+		throttler := NewThrottler()
+		throttler.SetQuota("Orders", "ListOrders", RequestQuota{MaxRequests: 1, RestoreRate: time.Second})
+
+		quota, _ := throttler.Quota("Orders", "ListOrders")
+		So(quota.MaxRequests, ShouldEqual, 1)
+		So(DefaultRequestQuotas["Orders/ListOrders"].MaxRequests, ShouldEqual, 6)
+	})
+}
+
+func TestThrottler_Wait(t *testing.T) {
+	restoreRate := 50 * time.Millisecond
+
+	Convey("Requests within quota are not blocked", t, func() {
+		throttler := NewThrottler()
+		throttler.SetQuota("Test", "Action", RequestQuota{MaxRequests: 2, RestoreRate: restoreRate})
+
+		start := time.Now()
+		So(throttler.Wait(context.Background(), "Test", "Action", "S1"), ShouldBeNil)
+		So(throttler.Wait(context.Background(), "Test", "Action", "S1"), ShouldBeNil)
+		So(time.Since(start), ShouldBeLessThan, restoreRate)
+	})
+
+	Convey("Request exceed quota is blocked until restored", t, func() {
+		throttler := NewThrottler()
+		throttler.SetQuota("Test", "Action", RequestQuota{MaxRequests: 1, RestoreRate: restoreRate})
+
+		start := time.Now()
+		throttler.Wait(context.Background(), "Test", "Action", "S1")
+		So(throttler.Wait(context.Background(), "Test", "Action", "S1"), ShouldBeNil)
+		So(time.Since(start), ShouldBeGreaterThanOrEqualTo, restoreRate*9/10)
+	})
+
+	Convey("Sellers have separate quotas", t, func() {
+		throttler := NewThrottler()
+		throttler.SetQuota("Test", "Action", RequestQuota{MaxRequests: 1, RestoreRate: time.Hour})
+
+		throttler.Wait(context.Background(), "Test", "Action", "S1")
+		ctx, cancel := context.WithTimeout(context.Background(), restoreRate)
+		defer cancel()
+		So(throttler.Wait(ctx, "Test", "Action", "S2"), ShouldBeNil)
+	})
+
+	Convey("Wait stop when context done", t, func() {
+		throttler := NewThrottler()
+		throttler.SetQuota("Test", "Action", RequestQuota{MaxRequests: 1, RestoreRate: time.Hour})
+
+		throttler.Wait(context.Background(), "Test", "Action", "S1")
+		ctx, cancel := context.WithTimeout(context.Background(), restoreRate)
+		defer cancel()
+		So(throttler.Wait(ctx, "Test", "Action", "S1"), ShouldEqual, context.DeadlineExceeded)
+	})
+
+	Convey("Operation without quota is not blocked", t, func() {
+		throttler := NewThrottler()
+		for i := 0; i < 100; i++ {
+			So(throttler.Wait(context.Background(), "Test", "Unknown", "S1"), ShouldBeNil)
+		}
+	})
+}
+
+func TestThrottler_Observe(t *testing.T) {
+	Convey("When hourly quota used up", t, func() {
+		throttler := NewThrottler()
+		throttler.Observe("Test", "Action", "S1", Quota{
+			Max:       200,
+			Remaining: 0,
+			ResetsOn:  time.Now().Add(time.Hour),
+		})
+
+		Convey("Requests blocked until quota reset", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			So(throttler.Wait(ctx, "Test", "Action", "S1"), ShouldEqual, context.DeadlineExceeded)
+		})
+
+		Convey("Requests from other sellers not blocked", func() {
+			So(throttler.Wait(context.Background(), "Test", "Action", "S2"), ShouldBeNil)
+		})
+
+		Convey("Requests not blocked once quota restored", func() {
+			throttler.Observe("Test", "Action", "S1", Quota{Max: 200, Remaining: 10})
+			So(throttler.Wait(context.Background(), "Test", "Action", "S1"), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,86 @@
+// Package throttling is the documented throttling quota of the MWS
+// 	operations, shared by the mws Throttler and the mock server.
+package throttling
+
+import "time"
+
+// Quota is the throttling limit of an operation.
+// http://docs.developer.amazonservices.com/en_US/dev_guide/DG_Throttling.html
+type Quota struct {
+	// Max number of requests can be sent at once.
+	MaxRequests int
+	// Time to restore one request.
+	RestoreRate time.Duration
+	// Max number of requests in an hour, only enforced by the mock server,
+	// 	the mws Throttler learn it from the quota headers of the responses.
+	// Default to the number of requests restored in an hour.
+	HourlyRequests int
+}
+
+// Hourly return the max number of requests in an hour, at least 1 when
+// 	the restore rate is longer than an hour.
+func (q Quota) Hourly() int {
+	if q.HourlyRequests > 0 {
+		return q.HourlyRequests
+	}
+	if q.RestoreRate <= 0 {
+		return 0
+	}
+	if n := int(time.Hour / q.RestoreRate); n > 1 {
+		return n
+	}
+	return 1
+}
+
+// Key generate the key of the operation in the quota tables,
+// 	ex: "Orders/ListOrders".
+func Key(name, action string) string {
+	return name + "/" + action
+}
+
+// DefaultQuotas the documented quota for the operations, key by
+// 	"Name/Action", ex: "Orders/ListOrders".
+var DefaultQuotas = map[string]Quota{
+	"Orders/ListOrders":                {MaxRequests: 6, RestoreRate: time.Minute},
+	"Orders/ListOrdersByNextToken":     {MaxRequests: 6, RestoreRate: time.Minute},
+	"Orders/GetOrder":                  {MaxRequests: 6, RestoreRate: time.Minute},
+	"Orders/ListOrderItems":            {MaxRequests: 30, RestoreRate: 2 * time.Second},
+	"Orders/ListOrderItemsByNextToken": {MaxRequests: 30, RestoreRate: 2 * time.Second},
+	"Orders/GetServiceStatus":          {MaxRequests: 2, RestoreRate: 5 * time.Minute},
+
+	"Products/ListMatchingProducts":          {MaxRequests: 20, RestoreRate: 5 * time.Second},
+	"Products/GetMatchingProduct":            {MaxRequests: 20, RestoreRate: 500 * time.Millisecond},
+	"Products/GetMatchingProductForId":       {MaxRequests: 20, RestoreRate: 200 * time.Millisecond},
+	"Products/GetCompetitivePricingForSKU":   {MaxRequests: 20, RestoreRate: 100 * time.Millisecond},
+	"Products/GetCompetitivePricingForASIN":  {MaxRequests: 20, RestoreRate: 100 * time.Millisecond},
+	"Products/GetLowestOfferListingsForSKU":  {MaxRequests: 20, RestoreRate: 100 * time.Millisecond},
+	"Products/GetLowestOfferListingsForASIN": {MaxRequests: 20, RestoreRate: 100 * time.Millisecond},
+	"Products/GetLowestPricedOffersForSKU":   {MaxRequests: 10, RestoreRate: 200 * time.Millisecond},
+	"Products/GetLowestPricedOffersForASIN":  {MaxRequests: 10, RestoreRate: 200 * time.Millisecond},
+	"Products/GetMyPriceForSKU":              {MaxRequests: 20, RestoreRate: 100 * time.Millisecond},
+	"Products/GetMyPriceForASIN":             {MaxRequests: 20, RestoreRate: 100 * time.Millisecond},
+	"Products/GetProductCategoriesForSKU":    {MaxRequests: 20, RestoreRate: 5 * time.Second},
+	"Products/GetProductCategoriesForASIN":   {MaxRequests: 20, RestoreRate: 5 * time.Second},
+	"Products/GetServiceStatus":              {MaxRequests: 2, RestoreRate: 5 * time.Minute},
+
+	"Reports/RequestReport":                   {MaxRequests: 15, RestoreRate: time.Minute},
+	"Reports/GetReportRequestList":            {MaxRequests: 10, RestoreRate: 45 * time.Second},
+	"Reports/GetReportRequestListByNextToken": {MaxRequests: 30, RestoreRate: 2 * time.Second},
+	"Reports/GetReportRequestCount":           {MaxRequests: 10, RestoreRate: 45 * time.Second},
+	"Reports/CancelReportRequests":            {MaxRequests: 10, RestoreRate: 45 * time.Second},
+	"Reports/GetReportList":                   {MaxRequests: 10, RestoreRate: time.Minute},
+	"Reports/GetReportListByNextToken":        {MaxRequests: 30, RestoreRate: 2 * time.Second},
+	"Reports/GetReportCount":                  {MaxRequests: 10, RestoreRate: 45 * time.Second},
+	"Reports/GetReport":                       {MaxRequests: 15, RestoreRate: time.Minute},
+	"Reports/ManageReportSchedule":            {MaxRequests: 10, RestoreRate: 45 * time.Second},
+	"Reports/GetReportScheduleList":           {MaxRequests: 10, RestoreRate: 45 * time.Second},
+	"Reports/GetReportScheduleCount":          {MaxRequests: 10, RestoreRate: 45 * time.Second},
+	"Reports/UpdateReportAcknowledgements":    {MaxRequests: 10, RestoreRate: 45 * time.Second},
+
+	"Feeds/SubmitFeed":                       {MaxRequests: 15, RestoreRate: 2 * time.Minute},
+	"Feeds/GetFeedSubmissionList":            {MaxRequests: 10, RestoreRate: 45 * time.Second},
+	"Feeds/GetFeedSubmissionListByNextToken": {MaxRequests: 30, RestoreRate: 2 * time.Second},
+	"Feeds/GetFeedSubmissionCount":           {MaxRequests: 10, RestoreRate: 45 * time.Second},
+	"Feeds/CancelFeedSubmissions":            {MaxRequests: 10, RestoreRate: 45 * time.Second},
+	"Feeds/GetFeedSubmissionResult":          {MaxRequests: 15, RestoreRate: time.Minute},
+}
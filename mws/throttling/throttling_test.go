@@ -0,0 +1,19 @@
+package throttling
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestQuota_Hourly(t *testing.T) {
+	Convey("Number of requests restored in an hour", t, func() {
+		So(Quota{MaxRequests: 6, RestoreRate: time.Minute}.Hourly(), ShouldEqual, 60)
+		So(Quota{MaxRequests: 6, RestoreRate: time.Minute, HourlyRequests: 10}.Hourly(), ShouldEqual, 10)
+	})
+
+	Convey("At least 1 when the restore rate is longer than an hour", t, func() {
+		So(Quota{MaxRequests: 1, RestoreRate: 2 * time.Hour}.Hourly(), ShouldEqual, 1)
+	})
+}